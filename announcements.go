@@ -0,0 +1,346 @@
+package shaman
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+// Politeness tells how urgently a screen reader announces a change to a live
+// region.
+type Politeness string
+
+const (
+	// Polite announcements are read when the user is idle.
+	Polite Politeness = "polite"
+	// Assertive announcements interrupt the user.
+	Assertive Politeness = "assertive"
+)
+
+// Announcement is a message a screen reader would announce when the content of
+// a [live region] changes.
+//
+// [live region]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Guides/Live_regions
+type Announcement struct {
+	Text       string
+	Politeness Politeness
+	// Region is the live region that changed.
+	Region dom.Element
+}
+
+// An AnnouncementPredicate checks if an announcement matches certain criteria.
+// Like [ElementPredicate], implementations should also implement
+// [fmt.Stringer] for better error messages.
+type AnnouncementPredicate interface{ IsMatch(Announcement) bool }
+
+// AnnouncementPredicateFunc wraps a function as an [AnnouncementPredicate].
+type AnnouncementPredicateFunc func(Announcement) bool
+
+func (f AnnouncementPredicateFunc) IsMatch(a Announcement) bool { return f(a) }
+
+// AnnouncementText is an [AnnouncementPredicate] matching announcements
+// containing the text.
+type AnnouncementText string
+
+func (s AnnouncementText) IsMatch(a Announcement) bool { return strings.Contains(a.Text, string(s)) }
+
+func (s AnnouncementText) String() string {
+	return fmt.Sprintf("Announcement containing: %s", string(s))
+}
+
+// waitTimeout is the maximum time to wait for the browser to process events
+// when waiting for an announcement.
+const waitTimeout = time.Second
+
+// Announcements records the changes to live regions in the scope, that a
+// screen reader would announce. Recording starts when created with
+// [Scope.Announcements], and stops when the test completes.
+//
+// Live regions are elements with aria-live, or the roles "alert", "status", or
+// "log". Changes are announced as a screen reader would, respecting
+// aria-atomic and aria-relevant: By default, added content is announced; and
+// the entire region is announced for an atomic region, e.g., an alert. An
+// alert is also announced when inserted into the document.
+//
+// For a window scope, recording continues after navigation, but content of the
+// new page isn't announced.
+type Announcements struct {
+	t       testing.TB
+	root    containerer
+	clock   html.Clock
+	node    dom.Node
+	closer  dom.Closer
+	records []Announcement
+	waited  int
+}
+
+// Announcements starts recording the announcements of live regions in the
+// scope.
+func (s Scope) Announcements() *Announcements {
+	a := &Announcements{t: s.t, root: s.root}
+	if w, ok := s.root.(windowContainerer); ok && w.win != nil {
+		a.clock = w.win.Clock()
+	}
+	a.observe()
+	if s.t != nil {
+		s.t.Cleanup(a.Stop)
+	}
+	return a
+}
+
+// observe ensures the current container is observed, as the document of a
+// window changes on navigation.
+func (a *Announcements) observe() {
+	c := a.root.container()
+	if c == nil || (a.node != nil && a.node == dom.Node(c)) {
+		return
+	}
+	a.Stop()
+	a.node = c
+	a.closer = c.Observe(announcementObserver{a})
+}
+
+// Stop stops recording announcements.
+func (a *Announcements) Stop() {
+	if a.closer != nil {
+		a.closer.Close()
+		a.closer = nil
+	}
+	a.node = nil
+}
+
+// All returns all announcements in the order they were made.
+func (a *Announcements) All() []Announcement {
+	a.observe()
+	return slices.Clone(a.records)
+}
+
+// Texts returns the text of all announcements in the order they were made.
+func (a *Announcements) Texts() []string {
+	a.observe()
+	res := make([]string, len(a.records))
+	for i, r := range a.records {
+		res[i] = r.Text
+	}
+	return res
+}
+
+// WaitForAnnouncement returns the first announcement matching the predicate,
+// made after the announcement returned by the previous call. For a window
+// scope, pending events are processed until a matching announcement is made,
+// e.g., the response of an HTMX request. A fatal error is generated if no
+// matching announcement is made.
+func (a *Announcements) WaitForAnnouncement(p AnnouncementPredicate) Announcement {
+	a.t.Helper()
+	a.observe()
+	find := func() int {
+		i := slices.IndexFunc(a.records[a.waited:], p.IsMatch)
+		if i == -1 {
+			return -1
+		}
+		return a.waited + i
+	}
+	i := find()
+	if i == -1 && a.clock != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+		defer cancel()
+		a.clock.ProcessEventsWhile(ctx, func() bool { i = find(); return i == -1 })
+	}
+	if i == -1 {
+		desc := "matching predicate"
+		if s, ok := p.(fmt.Stringer); ok {
+			desc = s.String()
+		}
+		a.t.Fatalf("No announcement: %s\nAnnouncements: %q", desc, a.Texts()[a.waited:])
+		return Announcement{}
+	}
+	a.waited = i + 1
+	return a.records[i]
+}
+
+type announcementObserver struct{ a *Announcements }
+
+func (o announcementObserver) Process(e dom.ChangeEvent) { o.a.process(e) }
+
+func (a *Announcements) process(e dom.ChangeEvent) {
+	target, ok := e.Target.(dom.Element)
+	if !ok {
+		target = e.Target.ParentElement()
+	}
+	if target == nil {
+		return
+	}
+	region, politeness := liveRegion(target)
+	if region == nil {
+		if e.Type == dom.ChangeEventChildList {
+			a.announceInsertedAlerts(e.AddedNodes)
+		}
+		return
+	}
+	relevant := liveRegionRelevant(target, region)
+	atomic := atomicRoot(target, region)
+	announce := func(nodes ...dom.Node) {
+		if atomic != nil {
+			nodes = []dom.Node{atomic}
+		}
+		texts := make([]string, 0, len(nodes))
+		for _, n := range nodes {
+			if text := announcementText(n); text != "" {
+				texts = append(texts, text)
+			}
+		}
+		if len(texts) > 0 {
+			a.records = append(a.records, Announcement{
+				Text:       strings.Join(texts, " "),
+				Politeness: politeness,
+				Region:     region,
+			})
+		}
+	}
+	switch e.Type {
+	case dom.ChangeEventChildList:
+		added, removed := nodes(e.AddedNodes), nodes(e.RemovedNodes)
+		switch {
+		case relevant["additions"] && len(added) > 0:
+			announce(added...)
+		case relevant["removals"] && len(removed) > 0:
+			announce(removed...)
+		}
+	case dom.ChangeEventCData:
+		if relevant["text"] {
+			announce(e.Target)
+		}
+	}
+}
+
+// announceInsertedAlerts announces elements with the role "alert" inserted
+// into the document.
+func (a *Announcements) announceInsertedAlerts(added dom.NodeList) {
+	for _, n := range nodes(added) {
+		e, ok := n.(dom.Element)
+		if !ok {
+			continue
+		}
+		for alert := range NewScope(nil, e).FindAll(ByRole(ariarole.Alert)) {
+			if text := announcementText(alert); text != "" {
+				a.records = append(a.records, Announcement{text, Assertive, alert})
+			}
+		}
+	}
+}
+
+func nodes(l dom.NodeList) []dom.Node {
+	if l == nil {
+		return nil
+	}
+	return l.All()
+}
+
+// liveRegion returns the live region containing e, and its politeness. Returns
+// nil if e is not inside a live region, or inside a region with
+// aria-live="off".
+func liveRegion(e dom.Element) (dom.Element, Politeness) {
+	for ; e != nil; e = e.ParentElement() {
+		switch live, _ := e.GetAttribute("aria-live"); live {
+		case "polite":
+			return e, Polite
+		case "assertive":
+			return e, Assertive
+		case "off":
+			return nil, ""
+		}
+		switch ariarole.GetElementRole(e) {
+		case ariarole.Alert:
+			return e, Assertive
+		case ariarole.Status, ariarole.Log:
+			return e, Polite
+		case ariarole.Timer, ariarole.Marquee:
+			return nil, ""
+		}
+	}
+	return nil, ""
+}
+
+// atomicRoot returns the element to announce in its entirety when e changes;
+// the nearest element with aria-atomic="true", or the region itself for
+// alerts, and status regions. Returns nil if changes should be announced
+// individually.
+func atomicRoot(e dom.Element, region dom.Element) dom.Element {
+	for ; e != nil; e = e.ParentElement() {
+		if v, ok := e.GetAttribute("aria-atomic"); ok {
+			if v == "true" {
+				return e
+			}
+			return nil
+		}
+		if e == region {
+			break
+		}
+	}
+	switch ariarole.GetElementRole(region) {
+	case ariarole.Alert, ariarole.Status:
+		return region
+	}
+	return nil
+}
+
+// liveRegionRelevant returns the types of changes to announce, as specified by
+// the nearest aria-relevant attribute, defaulting to "additions text".
+func liveRegionRelevant(e dom.Element, region dom.Element) map[string]bool {
+	value := "additions text"
+	for ; e != nil; e = e.ParentElement() {
+		if v, ok := e.GetAttribute("aria-relevant"); ok {
+			value = v
+			break
+		}
+		if e == region {
+			break
+		}
+	}
+	res := make(map[string]bool)
+	for _, v := range strings.Fields(value) {
+		if v == "all" {
+			return map[string]bool{"additions": true, "removals": true, "text": true}
+		}
+		res[v] = true
+	}
+	return res
+}
+
+// announcementText returns the text of a node with whitespace collapsed.
+// Hidden elements, and nodes inside hidden elements, have no text.
+func announcementText(n dom.Node) string {
+	e, ok := n.(dom.Element)
+	if !ok {
+		e = n.ParentElement()
+	}
+	if e != nil && ElementHidden(e) {
+		return ""
+	}
+	var b strings.Builder
+	writeVisibleText(&b, n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// writeVisibleText writes the text content of n, excluding the content of
+// hidden descendants.
+func writeVisibleText(b *strings.Builder, n dom.Node) {
+	if _, ok := n.(dom.Element); !ok {
+		b.WriteString(n.TextContent())
+		return
+	}
+	for _, c := range n.ChildNodes().All() {
+		if e, ok := c.(dom.Element); ok && ElementHidden(e) {
+			continue
+		}
+		writeVisibleText(b, c)
+	}
+}
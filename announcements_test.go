@@ -0,0 +1,93 @@
+package shaman_test
+
+import (
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnouncements(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<div id="status" role="status"></div>
+		<ul id="log" role="log"><li>Connected</li></ul>
+		<div id="polite" aria-live="polite"><span id="count">1</span> items</div>
+		<div id="atomic" aria-live="assertive" aria-atomic="true">
+			<span id="atomic-count">1</span> errors
+		</div>
+		<div id="off" aria-live="off"></div>
+		<div id="plain"></div>`)
+	announcements := NewScope(t, doc).Announcements()
+	get := doc.GetElementById
+
+	get("status").SetTextContent("Saved")
+	assert.Equal(t, []Announcement{{"Saved", Polite, get("status")}}, announcements.All())
+
+	item := doc.CreateElement("li")
+	item.SetTextContent("Message received")
+	get("log").AppendChild(item)
+	get("count").SetTextContent("2")
+	get("atomic-count").SetTextContent("3")
+	get("off").SetTextContent("Ignored")
+	get("plain").SetTextContent("Ignored")
+	assert.Equal(t, []string{
+		"Saved",
+		"Message received",
+		"2",
+		"3 errors",
+	}, announcements.Texts(), "Only the changed content is announced, unless atomic")
+	assert.Equal(t, Assertive, announcements.All()[3].Politeness)
+
+	get("log").RemoveChild(item)
+	assert.Len(t, announcements.All(), 4, "Removals are not announced by default")
+
+	alert := doc.CreateElement("div")
+	alert.SetAttribute("role", "alert")
+	alert.SetTextContent("Session expires soon")
+	get("plain").AppendChild(alert)
+
+	a := announcements.WaitForAnnouncement(AnnouncementText("Saved"))
+	assert.Equal(t, get("status"), a.Region)
+	a = announcements.WaitForAnnouncement(AnnouncementText("expires"))
+	assert.Equal(t, Announcement{"Session expires soon", Assertive, alert}, a,
+		"Inserted alerts are announced")
+
+	announcements.Stop()
+	get("status").SetTextContent("Not recorded")
+	assert.Len(t, announcements.All(), 5)
+}
+
+func TestAnnouncementsRelevant(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<ul id="list" aria-live="polite" aria-relevant="removals">
+			<li id="first">First</li>
+			<li>Second</li>
+		</ul>`)
+	announcements := NewScope(t, doc).Announcements()
+	list := doc.GetElementById("list")
+
+	item := doc.CreateElement("li")
+	item.SetTextContent("Third")
+	list.AppendChild(item)
+	list.RemoveChild(doc.GetElementById("first"))
+	assert.Equal(t, []string{"First"}, announcements.Texts())
+}
+
+func TestAnnouncementsHidden(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<div id="status" role="status">
+			<div id="hidden" hidden></div>
+		</div>`)
+	announcements := NewScope(t, doc).Announcements()
+	status := doc.GetElementById("status")
+
+	doc.GetElementById("hidden").SetTextContent("Hidden")
+	p := doc.CreateElement("p")
+	p.SetInnerHTML(`Saved <span aria-hidden="true">✓</span><input type="hidden" value="x">`)
+	status.AppendChild(p)
+	assert.Equal(t, []string{"Saved"}, announcements.Texts())
+}
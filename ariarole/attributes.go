@@ -0,0 +1,293 @@
+package ariarole
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gost-dom/browser/dom"
+)
+
+// valueType is the type of the value of an ARIA attribute.
+//
+// See also: https://www.w3.org/TR/wai-aria-1.2/#propcharacteristic_value
+type valueType int
+
+const (
+	stringValue valueType = iota
+	trueFalse
+	trueFalseUndefined
+	tristate
+	idReference
+	idReferenceList
+	integer
+	number
+	token
+	tokenList
+)
+
+// attribute describes an ARIA attribute. Roles is nil for global attributes,
+// which are permitted on all roles.
+type attribute struct {
+	typ    valueType
+	tokens []string
+	roles  []Role
+}
+
+var (
+	rangeRoles = []Role{Meter, Progressbar, Scrollbar, Separator, Slider, Spinbutton}
+	cellRoles  = []Role{Cell, Columnheader, Gridcell, Rowheader}
+	setRoles   = []Role{
+		Article, Listitem, Menuitem, Menuitemcheckbox, Menuitemradio, Option, Radio,
+		Row, Tab, Treeitem,
+	}
+	tableRoles = []Role{Table, Grid, Treegrid}
+)
+
+// attributes contains the states and properties defined by [WAI-ARIA 1.2].
+//
+// [WAI-ARIA 1.2]: https://www.w3.org/TR/wai-aria-1.2/#state_prop_def
+var attributes = map[string]attribute{
+	// Global states and properties
+	"aria-atomic":   {typ: trueFalse},
+	"aria-busy":     {typ: trueFalse},
+	"aria-controls": {typ: idReferenceList},
+	"aria-current": {token, []string{
+		"page", "step", "location", "date", "time", "true", "false",
+	}, nil},
+	"aria-describedby": {typ: idReferenceList},
+	"aria-description": {typ: stringValue},
+	"aria-details":     {typ: idReference},
+	"aria-disabled":    {typ: trueFalse},
+	"aria-dropeffect": {tokenList, []string{
+		"copy", "execute", "link", "move", "none", "popup",
+	}, nil},
+	"aria-errormessage": {typ: idReference},
+	"aria-flowto":       {typ: idReferenceList},
+	"aria-grabbed":      {typ: trueFalseUndefined},
+	"aria-haspopup": {token, []string{
+		"false", "true", "menu", "listbox", "tree", "grid", "dialog",
+	}, nil},
+	"aria-hidden":          {typ: trueFalseUndefined},
+	"aria-invalid":         {token, []string{"grammar", "false", "spelling", "true"}, nil},
+	"aria-keyshortcuts":    {typ: stringValue},
+	"aria-label":           {typ: stringValue},
+	"aria-labelledby":      {typ: idReferenceList},
+	"aria-live":            {token, []string{"assertive", "off", "polite"}, nil},
+	"aria-owns":            {typ: idReferenceList},
+	"aria-relevant":        {tokenList, []string{"additions", "all", "removals", "text"}, nil},
+	"aria-roledescription": {typ: stringValue},
+
+	// Role specific states and properties
+	"aria-activedescendant": {typ: idReference, roles: []Role{
+		Application, Combobox, Grid, Group, Listbox, Menu, Menubar, Radiogroup,
+		Searchbox, Spinbutton, Tablist, Textbox, Toolbar, Tree, Treegrid,
+	}},
+	"aria-autocomplete": {token, []string{"inline", "list", "both", "none"}, []Role{
+		Combobox, Searchbox, Textbox,
+	}},
+	"aria-checked": {typ: tristate, roles: []Role{
+		Checkbox, Menuitemcheckbox, Menuitemradio, Option, Radio, Switch, Treeitem,
+	}},
+	"aria-colcount": {typ: integer, roles: tableRoles},
+	"aria-colindex": {typ: integer, roles: append([]Role{Row}, cellRoles...)},
+	"aria-colspan":  {typ: integer, roles: cellRoles},
+	"aria-expanded": {typ: trueFalseUndefined, roles: []Role{
+		Application, Button, Checkbox, Columnheader, Combobox, Gridcell, Link, Listbox,
+		Menuitem, Menuitemcheckbox, Menuitemradio, Row, Rowheader, Switch, Tab, Treeitem,
+	}},
+	"aria-level":           {typ: integer, roles: []Role{Heading, Listitem, Row, Treeitem}},
+	"aria-modal":           {typ: trueFalse, roles: []Role{Alertdialog, Dialog}},
+	"aria-multiline":       {typ: trueFalse, roles: []Role{Searchbox, Textbox}},
+	"aria-multiselectable": {typ: trueFalse, roles: []Role{Grid, Listbox, Tablist, Tree, Treegrid}},
+	"aria-orientation": {token, []string{"horizontal", "vertical", "undefined"}, []Role{
+		Listbox, Menu, Menubar, Radiogroup, Scrollbar, Separator, Slider, Tablist,
+		Toolbar, Tree, Treegrid,
+	}},
+	"aria-placeholder": {typ: stringValue, roles: []Role{Searchbox, Textbox}},
+	"aria-posinset":    {typ: integer, roles: setRoles},
+	"aria-pressed":     {typ: tristate, roles: []Role{Button}},
+	"aria-readonly": {typ: trueFalse, roles: []Role{
+		Checkbox, Columnheader, Combobox, Grid, Gridcell, Listbox, Menuitemcheckbox,
+		Menuitemradio, Radiogroup, Rowheader, Searchbox, Slider, Spinbutton, Switch,
+		Textbox, Treegrid,
+	}},
+	"aria-required": {typ: trueFalse, roles: []Role{
+		Checkbox, Columnheader, Combobox, Gridcell, Listbox, Radiogroup, Rowheader,
+		Searchbox, Spinbutton, Switch, Textbox, Tree, Treegrid,
+	}},
+	"aria-rowcount": {typ: integer, roles: tableRoles},
+	"aria-rowindex": {typ: integer, roles: append([]Role{Row}, cellRoles...)},
+	"aria-rowspan":  {typ: integer, roles: cellRoles},
+	"aria-selected": {typ: trueFalseUndefined, roles: []Role{
+		Columnheader, Gridcell, Option, Row, Rowheader, Tab, Treeitem,
+	}},
+	"aria-setsize": {typ: integer, roles: setRoles},
+	"aria-sort": {token, []string{"ascending", "descending", "none", "other"}, []Role{
+		Columnheader, Rowheader,
+	}},
+	"aria-valuemax":  {typ: number, roles: rangeRoles},
+	"aria-valuemin":  {typ: number, roles: rangeRoles},
+	"aria-valuenow":  {typ: number, roles: rangeRoles},
+	"aria-valuetext": {typ: stringValue, roles: rangeRoles},
+}
+
+// genericElements are elements without a role, where role specific attributes
+// are not permitted. Other elements without a role in this package may have a
+// role not yet supported, so role specific attributes are accepted.
+var genericElements = map[string]bool{"DIV": true, "SPAN": true, "P": true}
+
+// AttributeError describes an invalid ARIA attribute of an element.
+type AttributeError struct {
+	Attribute string
+	Value     string
+	Message   string
+}
+
+func (e AttributeError) Error() string {
+	return fmt.Sprintf("%s=%q: %s", e.Attribute, e.Value, e.Message)
+}
+
+// ValidateElement validates the aria-* attributes of element e. An attribute
+// must be defined by WAI-ARIA; have a valid value, e.g., "true" or "false";
+// be permitted on the role of the element; and ID references must resolve to
+// elements in the document.
+//
+// Returns nil if all attributes are valid.
+func ValidateElement(e dom.Element) []AttributeError {
+	var res []AttributeError
+	for a := range e.Attributes().All() {
+		name := strings.ToLower(a.Name())
+		if !strings.HasPrefix(name, "aria-") {
+			continue
+		}
+		if msg := validateAttribute(e, name, a.Value()); msg != "" {
+			res = append(res, AttributeError{name, a.Value(), msg})
+		}
+	}
+	return res
+}
+
+func validateAttribute(e dom.Element, name, value string) string {
+	attr, ok := attributes[name]
+	if !ok {
+		if s := suggestAttribute(name); s != "" {
+			return fmt.Sprintf("unknown ARIA attribute, did you mean %s?", s)
+		}
+		return "unknown ARIA attribute"
+	}
+	if attr.roles != nil {
+		switch role := elementRole(e); {
+		case role == None && genericElements[e.TagName()]:
+			return "not permitted on an element without a role"
+		case role != None && !slices.Contains(attr.roles, role):
+			return fmt.Sprintf("not permitted on role %q", role)
+		}
+	}
+	if value == "" {
+		return ""
+	}
+	switch attr.typ {
+	case trueFalse:
+		return tokens(value, "true", "false")
+	case trueFalseUndefined:
+		return tokens(value, "true", "false", "undefined")
+	case tristate:
+		return tokens(value, "true", "false", "mixed", "undefined")
+	case token:
+		return tokens(value, attr.tokens...)
+	case tokenList:
+		for _, v := range strings.Fields(value) {
+			if msg := tokens(v, attr.tokens...); msg != "" {
+				return msg
+			}
+		}
+	case integer:
+		if _, err := strconv.Atoi(value); err != nil {
+			return "must be an integer"
+		}
+	case number:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "must be a number"
+		}
+	case idReference:
+		if len(strings.Fields(value)) != 1 {
+			return "must be a single ID reference"
+		}
+		return resolveIDs(e, value)
+	case idReferenceList:
+		return resolveIDs(e, value)
+	}
+	return ""
+}
+
+// elementRole returns the role of e, using the first valid role of a role
+// attribute with fallback roles.
+func elementRole(e dom.Element) Role {
+	role := GetElementRole(e)
+	if role == PasswordText {
+		return Textbox
+	}
+	for _, r := range strings.Fields(string(role)) {
+		if knownRoles[Role(r)] {
+			return Role(r)
+		}
+	}
+	return role
+}
+
+func tokens(value string, allowed ...string) string {
+	if slices.Contains(allowed, value) {
+		return ""
+	}
+	return fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", "))
+}
+
+func resolveIDs(e dom.Element, value string) string {
+	doc := e.OwnerDocument()
+	if doc == nil {
+		return ""
+	}
+	for _, id := range strings.Fields(value) {
+		if doc.GetElementById(id) == nil {
+			return fmt.Sprintf("no element with id %q", id)
+		}
+	}
+	return ""
+}
+
+// suggestAttribute returns a known attribute with a name close to name, e.g.,
+// "aria-labelledby" for the common misspelling "aria-labeledby".
+func suggestAttribute(name string) string {
+	var res string
+	var best int
+	for a := range attributes {
+		d := editDistance(name, a)
+		if d <= 2 && (res == "" || d < best || d == best && a < res) {
+			res, best = a, d
+		}
+	}
+	return res
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
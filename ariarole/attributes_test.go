@@ -0,0 +1,59 @@
+package ariarole_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/html"
+)
+
+func TestValidateElement(t *testing.T) {
+	win, err := html.NewWindowReader(strings.NewReader(`<body>
+		<span id="hint">Hint</span>
+		<span id="label">Name</span>
+		<input id="valid" aria-labelledby="label" aria-describedby="hint"
+			aria-required="true" aria-invalid="spelling" />
+		<input id="typo" aria-labeledby="label" />
+		<input id="unknown-id" aria-describedby="hint missing" />
+		<button id="bad-value" aria-pressed="yes" aria-expanded="false">Bold</button>
+		<div id="not-permitted" aria-checked="true">Terms</div>
+		<div id="fallback" role="switch checkbox" aria-checked="mixed"></div>
+		<div id="unknown" aria-foo="bar"></div>
+		<div id="integer" role="heading" aria-level="two">Orders</div>
+		<div id="empty" role="slider" aria-valuenow=""></div>
+	</body>`))
+	if err != nil {
+		t.Fatalf("Error parsing HTML: %v", err)
+	}
+	doc := win.Document()
+
+	for id, want := range map[string]string{
+		"valid":         "",
+		"typo":          `aria-labeledby="label": unknown ARIA attribute, did you mean aria-labelledby?`,
+		"unknown-id":    `aria-describedby="hint missing": no element with id "missing"`,
+		"bad-value":     `aria-pressed="yes": must be one of: true, false, mixed, undefined`,
+		"not-permitted": `aria-checked="true": not permitted on an element without a role`,
+		"fallback":      "",
+		"unknown":       `aria-foo="bar": unknown ARIA attribute`,
+		"integer":       `aria-level="two": must be an integer`,
+		"empty":         "",
+	} {
+		errs := ariarole.ValidateElement(doc.GetElementById(id))
+		var got []string
+		for _, err := range errs {
+			got = append(got, err.Error())
+		}
+		if strings.Join(got, "\n") != want {
+			t.Errorf("ValidateElement(#%s): expected %q, got %q", id, want, got)
+		}
+	}
+
+	link := doc.CreateElement("a")
+	link.SetAttribute("aria-selected", "true")
+	if errs := ariarole.ValidateElement(link); len(errs) != 1 ||
+		errs[0].Message != `not permitted on role "link"` {
+		t.Errorf("ValidateElement(<a aria-selected>): got %v", errs)
+	}
+}
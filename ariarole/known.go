@@ -0,0 +1,40 @@
+package ariarole
+
+import "strings"
+
+// knownRoles contains the roles defined by [WAI-ARIA 1.2], except abstract
+// roles, which must not be used in content.
+//
+// [WAI-ARIA 1.2]: https://www.w3.org/TR/wai-aria-1.2/#role_definitions
+var knownRoles = map[Role]bool{}
+
+func init() {
+	for _, r := range strings.Fields(`
+		alert alertdialog application article banner blockquote button caption
+		cell checkbox code columnheader combobox complementary contentinfo
+		definition deletion dialog directory document emphasis feed figure form
+		generic grid gridcell group heading img insertion link list listbox
+		listitem log main marquee math menu menubar menuitem menuitemcheckbox
+		menuitemradio meter navigation none note option paragraph presentation
+		progressbar radio radiogroup region row rowgroup rowheader scrollbar
+		search searchbox separator slider spinbutton status strong subscript
+		superscript switch tab table tablist tabpanel term textbox time timer
+		toolbar tooltip tree treegrid treeitem`,
+	) {
+		knownRoles[Role(r)] = true
+	}
+}
+
+// Valid returns whether r is a role defined by WAI-ARIA. A role attribute can
+// contain a list of fallback roles, e.g., role="switch checkbox", which is
+// valid if any of the roles are.
+//
+// [PasswordText] isn't a valid role.
+func (r Role) Valid() bool {
+	for _, r := range strings.Fields(string(r)) {
+		if knownRoles[Role(r)] {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,13 @@
 package ariarole
 
-import "github.com/gost-dom/browser/dom"
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gost-dom/shaman/node"
+
+	"github.com/gost-dom/browser/dom"
+)
 
 // Role represents an [ARIA role]. See package documentation for more
 // information of aria roles.
@@ -26,6 +33,17 @@ const (
 	Main   Role = "main"
 	Banner Role = "banner"
 
+	Navigation    Role = "navigation"
+	Complementary Role = "complementary"
+	Contentinfo   Role = "contentinfo"
+	Region        Role = "region"
+	Search        Role = "search"
+	Article       Role = "article"
+	Heading       Role = "heading"
+	Img           Role = "img"
+	List          Role = "list"
+	Listitem      Role = "listitem"
+
 	// PasswordText represents the "password text" role, which isn't an official
 	// ARIA role. It is reported by Firefox's accessibility tools, and helpful
 	// as password fields don't actually have an official role, i.e., you cannot
@@ -33,34 +51,215 @@ const (
 	PasswordText Role = "password text"
 	Textbox      Role = "textbox"
 	Checkbox     Role = "checkbox"
+	Combobox     Role = "combobox"
+	Listbox      Role = "listbox"
+	Option       Role = "option"
+	Group        Role = "group"
+	Radio        Role = "radio"
+	Radiogroup   Role = "radiogroup"
+	Table        Role = "table"
+	Grid         Role = "grid"
+	Caption      Role = "caption"
+	Rowgroup     Role = "rowgroup"
+	Row          Role = "row"
+	Cell         Role = "cell"
+	Gridcell     Role = "gridcell"
+	Columnheader Role = "columnheader"
+	Rowheader    Role = "rowheader"
+	Dialog       Role = "dialog"
+	Alertdialog  Role = "alertdialog"
+	Tablist      Role = "tablist"
+	Tab          Role = "tab"
+	Tabpanel     Role = "tabpanel"
+
+	Menu             Role = "menu"
+	Menubar          Role = "menubar"
+	Menuitem         Role = "menuitem"
+	Menuitemcheckbox Role = "menuitemcheckbox"
+	Menuitemradio    Role = "menuitemradio"
+
+	Tree     Role = "tree"
+	Treegrid Role = "treegrid"
+	Treeitem Role = "treeitem"
+
+	Slider      Role = "slider"
+	Spinbutton  Role = "spinbutton"
+	Progressbar Role = "progressbar"
+	Meter       Role = "meter"
+
+	Status  Role = "status"
+	Log     Role = "log"
+	Timer   Role = "timer"
+	Marquee Role = "marquee"
+
+	Application Role = "application"
+	Searchbox   Role = "searchbox"
+	Switch      Role = "switch"
+	Scrollbar   Role = "scrollbar"
+	Separator   Role = "separator"
+	Toolbar     Role = "toolbar"
 )
 
+// FormField returns whether r is a role of form fields, i.e., elements taking
+// input from the user, e.g., a textbox, or a checkbox.
+func (r Role) FormField() bool {
+	switch r {
+	case Textbox, Searchbox, PasswordText, Checkbox, Radio, Combobox, Listbox, Slider,
+		Spinbutton:
+		return true
+	}
+	return false
+}
+
+// HeadingLevel returns the level of a heading; the aria-level attribute, or
+// the level of an <h1>-<h6> element. Other headings default to level 2.
+func HeadingLevel(e dom.Element) int {
+	if v, ok := e.GetAttribute("aria-level"); ok {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	if t := e.TagName(); len(t) == 2 && t[0] == 'H' {
+		if n, err := strconv.Atoi(t[1:]); err == nil {
+			return n
+		}
+	}
+	return 2
+}
+
 var elementRoles map[string]Role = map[string]Role{
-	"MAIN":   Main,
-	"BUTTON": Button,
-	"A":      Link,
-	"FORM":   Form,
-	"HEADER": Banner,
+	"MAIN":     Main,
+	"BUTTON":   Button,
+	"A":        Link,
+	"FORM":     Form,
+	"HEADER":   Banner,
+	"OPTION":   Option,
+	"FIELDSET": Group,
+	"TABLE":    Table,
+	"CAPTION":  Caption,
+	"THEAD":    Rowgroup,
+	"TBODY":    Rowgroup,
+	"TFOOT":    Rowgroup,
+	"TR":       Row,
+	"TD":       Cell,
+	"DIALOG":   Dialog,
+	"PROGRESS": Progressbar,
+	"METER":    Meter,
+	"TEXTAREA": Textbox,
+	"OUTPUT":   Status,
+	"NAV":      Navigation,
+	"ASIDE":    Complementary,
+	"FOOTER":   Contentinfo,
+	"SEARCH":   Search,
+	"ARTICLE":  Article,
+	"H1":       Heading,
+	"H2":       Heading,
+	"H3":       Heading,
+	"H4":       Heading,
+	"H5":       Heading,
+	"H6":       Heading,
+	"IMG":      Img,
+	"UL":       List,
+	"OL":       List,
+	"LI":       Listitem,
 }
 
-func GetElementRole(e dom.Element) Role {
+// GetElementRole returns the role of element e; the role attribute, or the
+// implicit role of the element.
+func GetElementRole(e dom.Element) Role { return ElementRole(node.FromElement(e)) }
+
+// ElementRole returns the role of element e of any DOM implementation, see
+// package [node].
+func ElementRole(e node.Element) Role {
 	if r, ok := e.GetAttribute("role"); ok {
 		// TODO: check validity of r
 		return Role(r)
 	}
 	switch e.TagName() {
-	case "INPUT":
-		if t, ok := e.GetAttribute("type"); ok {
-			switch t {
-			case "password":
-				return PasswordText
-			case "checkbox":
-				return Checkbox
-			case "button", "submit", "reset":
-				return Button
-			}
-			return Textbox
+	case "SELECT":
+		return selectRole(e)
+	case "TH":
+		return headerCellRole(e)
+	case "SECTION":
+		// A <section> is only a region landmark when it has a name
+		if node.HasAttribute(e, "aria-label") || node.HasAttribute(e, "aria-labelledby") {
+			return Region
 		}
+		return None
+	case "INPUT":
+		return inputRole(e)
 	}
 	return elementRoles[e.TagName()]
 }
+
+// inputRole returns the role of an <input> element by its type. Types without
+// a corresponding role, e.g., "hidden", "file", and "date", have no role.
+// Unknown types are text fields, as in a browser.
+func inputRole(e node.Element) Role {
+	t, _ := e.GetAttribute("type")
+	switch strings.ToLower(t) {
+	case "password":
+		return PasswordText
+	case "checkbox":
+		return Checkbox
+	case "radio":
+		return Radio
+	case "range":
+		return Slider
+	case "number":
+		return Spinbutton
+	case "button", "submit", "reset", "image":
+		return Button
+	case "hidden", "file", "color", "date", "datetime-local", "month", "time", "week":
+		return None
+	case "search":
+		if node.HasAttribute(e, "list") {
+			return Combobox
+		}
+		return Searchbox
+	}
+	if node.HasAttribute(e, "list") {
+		return Combobox
+	}
+	return Textbox
+}
+
+// selectRole returns the role of a <select> element. A <select> is a
+// "combobox", unless it allows multiple selections, or displays more than one
+// option at a time, in which case it is a "listbox".
+func selectRole(e node.Element) Role {
+	if node.HasAttribute(e, "multiple") {
+		return Listbox
+	}
+	if size, ok := e.GetAttribute("size"); ok {
+		if n, err := strconv.Atoi(size); err == nil && n > 1 {
+			return Listbox
+		}
+	}
+	return Combobox
+}
+
+// headerCellRole returns the role of a <th> element, which is a "rowheader" if
+// it has scope="row", or is in a row containing data cells outside the table
+// head; otherwise a "columnheader".
+func headerCellRole(e node.Element) Role {
+	switch scope, _ := e.GetAttribute("scope"); scope {
+	case "row", "rowgroup":
+		return Rowheader
+	case "col", "colgroup":
+		return Columnheader
+	}
+	row := node.ParentElement(e)
+	if row == nil {
+		return Columnheader
+	}
+	if group := node.ParentElement(row); group != nil && group.TagName() == "THEAD" {
+		return Columnheader
+	}
+	for _, c := range node.Children(row) {
+		if c.TagName() == "TD" {
+			return Rowheader
+		}
+	}
+	return Columnheader
+}
@@ -20,6 +20,38 @@ func TestARIARoles(t *testing.T) {
 		{TagName: "button", RoleAttr: "button", Want: ariarole.Button},
 		{TagName: "", RoleAttr: "alert", Want: ariarole.Alert},
 		{TagName: "header", RoleAttr: "banner", Want: ariarole.Banner},
+		{TagName: "select", RoleAttr: "combobox", Want: ariarole.Combobox},
+		{TagName: "option", RoleAttr: "option", Want: ariarole.Option},
+		{TagName: "", RoleAttr: "listbox", Want: ariarole.Listbox},
+		{TagName: "fieldset", RoleAttr: "group", Want: ariarole.Group},
+		{TagName: "", RoleAttr: "radiogroup", Want: ariarole.Radiogroup},
+		{TagName: "table", RoleAttr: "table", Want: ariarole.Table},
+		{TagName: "tr", RoleAttr: "row", Want: ariarole.Row},
+		{TagName: "td", RoleAttr: "cell", Want: ariarole.Cell},
+		{TagName: "th", RoleAttr: "columnheader", Want: ariarole.Columnheader},
+		{TagName: "", RoleAttr: "grid", Want: ariarole.Grid},
+		{TagName: "dialog", RoleAttr: "dialog", Want: ariarole.Dialog},
+		{TagName: "", RoleAttr: "alertdialog", Want: ariarole.Alertdialog},
+		{TagName: "", RoleAttr: "tablist", Want: ariarole.Tablist},
+		{TagName: "", RoleAttr: "tab", Want: ariarole.Tab},
+		{TagName: "", RoleAttr: "tabpanel", Want: ariarole.Tabpanel},
+		{TagName: "", RoleAttr: "menu", Want: ariarole.Menu},
+		{TagName: "", RoleAttr: "menuitem", Want: ariarole.Menuitem},
+		{TagName: "", RoleAttr: "tree", Want: ariarole.Tree},
+		{TagName: "", RoleAttr: "treeitem", Want: ariarole.Treeitem},
+		{TagName: "progress", RoleAttr: "progressbar", Want: ariarole.Progressbar},
+		{TagName: "meter", RoleAttr: "meter", Want: ariarole.Meter},
+		{TagName: "", RoleAttr: "slider", Want: ariarole.Slider},
+		{TagName: "textarea", RoleAttr: "textbox", Want: ariarole.Textbox},
+		{TagName: "output", RoleAttr: "status", Want: ariarole.Status},
+		{TagName: "", RoleAttr: "log", Want: ariarole.Log},
+		{TagName: "nav", RoleAttr: "navigation", Want: ariarole.Navigation},
+		{TagName: "aside", RoleAttr: "complementary", Want: ariarole.Complementary},
+		{TagName: "footer", RoleAttr: "contentinfo", Want: ariarole.Contentinfo},
+		{TagName: "h2", RoleAttr: "heading", Want: ariarole.Heading},
+		{TagName: "img", RoleAttr: "img", Want: ariarole.Img},
+		{TagName: "ul", RoleAttr: "list", Want: ariarole.List},
+		{TagName: "li", RoleAttr: "listitem", Want: ariarole.Listitem},
 	}
 
 	for _, spec := range specs {
@@ -40,6 +72,94 @@ func TestARIARoles(t *testing.T) {
 	}
 }
 
+func TestSelectRole(t *testing.T) {
+	createElement := newRoleHelper().createElement
+
+	sel := createElement("select")
+	sel.SetAttribute("multiple", "")
+	assertRole(t, ariarole.Listbox, sel)
+
+	sel = createElement("select")
+	sel.SetAttribute("size", "4")
+	assertRole(t, ariarole.Listbox, sel)
+
+	sel = createElement("select")
+	sel.SetAttribute("size", "1")
+	assertRole(t, ariarole.Combobox, sel)
+
+	input := createElement("input")
+	input.SetAttribute("type", "text")
+	input.SetAttribute("list", "suggestions")
+	assertRole(t, ariarole.Combobox, input)
+}
+
+func TestInputRole(t *testing.T) {
+	createElement := newRoleHelper().createElement
+
+	for inputType, want := range map[string]ariarole.Role{
+		"text":     ariarole.Textbox,
+		"password": ariarole.PasswordText,
+		"checkbox": ariarole.Checkbox,
+		"radio":    ariarole.Radio,
+		"range":    ariarole.Slider,
+		"number":   ariarole.Spinbutton,
+		"submit":   ariarole.Button,
+		"image":    ariarole.Button,
+		"search":   ariarole.Searchbox,
+		"email":    ariarole.Textbox,
+		"unknown":  ariarole.Textbox,
+		"hidden":   ariarole.None,
+		"file":     ariarole.None,
+		"color":    ariarole.None,
+		"date":     ariarole.None,
+	} {
+		input := createElement("input")
+		input.SetAttribute("type", inputType)
+		assertRole(t, want, input)
+	}
+
+	assertRole(t, ariarole.Textbox, createElement("input"))
+}
+
+func TestSectionRole(t *testing.T) {
+	createElement := newRoleHelper().createElement
+
+	section := createElement("section")
+	assertRole(t, ariarole.None, section)
+	section.SetAttribute("aria-label", "Orders")
+	assertRole(t, ariarole.Region, section)
+}
+
+func TestRoleValid(t *testing.T) {
+	for role, want := range map[ariarole.Role]bool{
+		ariarole.Button:       true,
+		ariarole.Treegrid:     true,
+		"switch checkbox":     true,
+		"buton":               false,
+		"widget":              false,
+		"":                    false,
+		ariarole.PasswordText: false,
+	} {
+		if got := role.Valid(); got != want {
+			t.Errorf("Role(%q).Valid(): expected %v, got %v", role, want, got)
+		}
+	}
+}
+
+func TestHeadingLevel(t *testing.T) {
+	createElement := newRoleHelper().createElement
+	h3 := createElement("h3")
+	div := createElement("div")
+	div.SetAttribute("role", "heading")
+	h1 := createElement("h1")
+	h1.SetAttribute("aria-level", "4")
+	for e, want := range map[html.HTMLElement]int{h3: 3, div: 2, h1: 4} {
+		if got := ariarole.HeadingLevel(e); got != want {
+			t.Errorf("HeadingLevel(%s): expected %d, got %d", e.OuterHTML(), want, got)
+		}
+	}
+}
+
 type roleHelper struct {
 	doc html.HTMLDocument
 }
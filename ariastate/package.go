@@ -0,0 +1,14 @@
+// Package ariastate defines ARIA states, e.g., expanded, or checked.
+//
+// [ARIA states] describe the current condition of an element, and change as
+// the user interacts with the page, e.g., a disclosure button is expanded when
+// the content it controls is shown. A screen reader announces the states of an
+// element, e.g., "button, More, collapsed".
+//
+// A state is set by an aria- attribute, e.g., aria-expanded, or is inherent
+// to a native element, e.g., a checked checkbox, or a disabled button. See
+// [shaman.ElementHasState] for how states are determined.
+//
+// [ARIA states]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes#states_and_properties
+// [shaman.ElementHasState]: https://pkg.go.dev/github.com/gost-dom/shaman#ElementHasState
+package ariastate
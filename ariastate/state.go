@@ -0,0 +1,19 @@
+package ariastate
+
+// State represents an [ARIA state]. The value is the name of the state's
+// attribute without the "aria-" prefix.
+//
+// [ARIA state]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes#states_and_properties
+type State string
+
+const (
+	Busy     State = "busy"
+	Checked  State = "checked"
+	Current  State = "current"
+	Disabled State = "disabled"
+	Expanded State = "expanded"
+	Hidden   State = "hidden"
+	Invalid  State = "invalid"
+	Pressed  State = "pressed"
+	Selected State = "selected"
+)
@@ -0,0 +1,182 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/ariastate"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestingT is the interface of the test, compatible with [testing.TB], and
+// testify's assert.TestingT.
+type TestingT = assert.TestingT
+
+type tHelper interface{ Helper() }
+
+// HasRole asserts that the element has the [ARIA role].
+//
+// [ARIA role]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Roles
+func HasRole(t TestingT, e dom.Element, role ariarole.Role, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !notNil(t, e, msgAndArgs...) {
+		return false
+	}
+	if actual := ariarole.GetElementRole(e); actual != role {
+		return assert.Fail(t, unexpected("role", e, role, actual), msgAndArgs...)
+	}
+	return true
+}
+
+// HasName asserts that the element has the [accessibility name].
+//
+// [accessibility name]: https://developer.mozilla.org/en-US/docs/Glossary/Accessible_name
+func HasName(t TestingT, e dom.Element, name string, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !notNil(t, e, msgAndArgs...) {
+		return false
+	}
+	if actual := shaman.ElementName(e); actual != name {
+		return assert.Fail(t, unexpected("accessibility name", e, name, actual), msgAndArgs...)
+	}
+	return true
+}
+
+// HasDescription asserts that the element has the [accessibility description].
+//
+// [accessibility description]: https://w3c.github.io/accname/#dfn-accessible-description
+func HasDescription(t TestingT, e dom.Element, desc string, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !notNil(t, e, msgAndArgs...) {
+		return false
+	}
+	if actual := shaman.ElementDescription(e); actual != desc {
+		return assert.Fail(t, unexpected("accessibility description", e, desc, actual), msgAndArgs...)
+	}
+	return true
+}
+
+// HasState asserts that the element has the ARIA state.
+//
+// See also: [shaman.ElementHasState]
+func HasState(t TestingT, e dom.Element, s ariastate.State, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !notNil(t, e, msgAndArgs...) {
+		return false
+	}
+	if !shaman.ElementHasState(e, s) {
+		return assert.Fail(t, failure("Element is not "+string(s), element(e)), msgAndArgs...)
+	}
+	return true
+}
+
+// NotHasState asserts that the element doesn't have the ARIA state.
+func NotHasState(t TestingT, e dom.Element, s ariastate.State, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !notNil(t, e, msgAndArgs...) {
+		return false
+	}
+	if shaman.ElementHasState(e, s) {
+		return assert.Fail(t, failure("Element is "+string(s), element(e)), msgAndArgs...)
+	}
+	return true
+}
+
+// Exists asserts that the scope contains an element matching the predicates.
+func Exists(t TestingT, scope shaman.Scope, preds ...shaman.ElementPredicate) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	for range scope.FindAll(preds...) {
+		return true
+	}
+	return assert.Fail(t, failure("No element matching: "+shaman.DescribePredicates(preds...)))
+}
+
+// NotExists asserts that the scope doesn't contain an element matching the
+// predicates.
+func NotExists(t TestingT, scope shaman.Scope, preds ...shaman.ElementPredicate) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	matches := matching(scope, preds)
+	if len(matches) == 0 {
+		return true
+	}
+	return assert.Fail(t, failure(
+		"Unexpected element matching: "+shaman.DescribePredicates(preds...),
+		matchLines(matches)...,
+	))
+}
+
+// Count asserts that the scope contains n elements matching the predicates.
+func Count(t TestingT, scope shaman.Scope, n int, preds ...shaman.ElementPredicate) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	matches := matching(scope, preds)
+	if len(matches) == n {
+		return true
+	}
+	return assert.Fail(t, failure(
+		fmt.Sprintf("Expected %d elements matching: %s", n, shaman.DescribePredicates(preds...)),
+		append([]string{fmt.Sprintf("found   : %d", len(matches))}, matchLines(matches)...)...,
+	))
+}
+
+func matching(scope shaman.Scope, preds []shaman.ElementPredicate) []dom.Element {
+	var res []dom.Element
+	for e := range scope.FindAll(preds...) {
+		res = append(res, e)
+	}
+	return res
+}
+
+func matchLines(matches []dom.Element) []string {
+	res := make([]string, len(matches))
+	for i, e := range matches {
+		res[i] = fmt.Sprintf("match %d : %s", i+1, shaman.DescribeElement(e))
+	}
+	return res
+}
+
+func unexpected(property string, e dom.Element, expected, actual any) string {
+	return failure("Unexpected "+property,
+		element(e),
+		fmt.Sprintf("expected: %q", expected),
+		fmt.Sprintf("actual  : %q", actual),
+	)
+}
+
+// element returns the line describing an element in a failure.
+func element(e dom.Element) string { return "element : " + shaman.DescribeElementHTML(e) }
+
+// notNil fails the test if the element is nil, e.g., when not found by
+// [shaman.Scope.Find].
+func notNil(t TestingT, e dom.Element, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if e == nil {
+		return assert.Fail(t, "Expected an element, got nil", msgAndArgs...)
+	}
+	return true
+}
+
+func failure(summary string, lines ...string) string {
+	return strings.Join(append([]string{summary}, lines...), "\n")
+}
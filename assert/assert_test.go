@@ -0,0 +1,74 @@
+package assert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/ariastate"
+	. "github.com/gost-dom/shaman/assert"
+	"github.com/gost-dom/shaman/internal/testtb"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadScope(t testing.TB, h string) shaman.Scope {
+	win, err := html.NewWindowReader(strings.NewReader(h))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return shaman.NewScope(t, win.Document())
+}
+
+const page = `<body><main>
+	<label>E-mail <input type="email" id="email" aria-description="Used for sign in" /></label>
+	<button aria-expanded="true">More</button>
+	<ul><li>Shoes</li><li>Socks</li></ul>
+</main></body>`
+
+func TestAssertions(t *testing.T) {
+	t.Parallel()
+	scope := loadScope(t, page)
+	email := scope.Get(shaman.ByRole(ariarole.Textbox))
+	more := scope.Get(shaman.ByRole(ariarole.Button))
+
+	assert.True(t, HasRole(t, email, ariarole.Textbox))
+	assert.True(t, HasName(t, email, "E-mail"))
+	assert.True(t, HasDescription(t, email, "Used for sign in"))
+	assert.True(t, HasState(t, more, ariastate.Expanded))
+	assert.True(t, NotHasState(t, more, ariastate.Disabled))
+	assert.True(t, Exists(t, scope, shaman.ByRole(ariarole.Button), shaman.ByName("More")))
+	assert.True(t, NotExists(t, scope, shaman.ByRole(ariarole.Alert)))
+	assert.True(t, Count(t, scope, 2, shaman.ByRole(ariarole.Listitem)))
+}
+
+func TestAssertionFailures(t *testing.T) {
+	t.Parallel()
+	scope := loadScope(t, page)
+	email := scope.Get(shaman.ByRole(ariarole.Textbox))
+	more := scope.Get(shaman.ByRole(ariarole.Button))
+
+	mt := &testtb.RecordingTB{TB: t}
+	assert.False(t, HasName(mt, email, "Email", "Sign in form"))
+	assert.False(t, NotHasState(mt, more, ariastate.Expanded))
+	assert.False(t, Exists(mt, scope, shaman.ByRole(ariarole.Alert)))
+	assert.False(t, Count(mt, scope, 3, shaman.ByRole(ariarole.Listitem)))
+	assert.False(t, HasName(mt, scope.Find(shaman.ByRole(ariarole.Alert)), "Saved"))
+
+	assert.Len(t, mt.Errors, 5)
+	assert.Contains(t, mt.Errors[0], "Unexpected accessibility name\n"+
+		"\t            \telement : textbox \"E-mail\": "+
+		"<input type=\"email\" id=\"email\" aria-description=\"Used for sign in\"></input>\n"+
+		"\t            \texpected: \"Email\"\n"+
+		"\t            \tactual  : \"E-mail\"")
+	assert.Contains(t, mt.Errors[0], "Sign in form")
+	assert.Contains(t, mt.Errors[1], "Element is expanded\n\t            \telement : button \"More\"")
+	assert.Contains(t, mt.Errors[2], "No element matching: By role: alert")
+	assert.Contains(t, mt.Errors[3], "Expected 3 elements matching: By role: listitem\n"+
+		"\t            \tfound   : 2\n"+
+		"\t            \tmatch 1 : listitem \"Shoes\"\n"+
+		"\t            \tmatch 2 : listitem \"Socks\"")
+	assert.Contains(t, mt.Errors[4], "Expected an element, got nil")
+}
@@ -0,0 +1,21 @@
+// Package assert provides [testify] style assertions of the accessibility
+// properties of elements, and of the elements in a shaman scope.
+//
+// Like testify's assert package, the functions report a failure with
+// t.Errorf, and return whether the assertion succeeded, so the test continues.
+// Use the require package to stop the test on failure.
+//
+//	button := scope.Get(ByRole(ariarole.Button), ByName("More"))
+//	assert.HasState(t, button, ariastate.Expanded)
+//	assert.Count(t, scope, 3, ByRole(ariarole.Listitem))
+//
+// Failures describe the elements by role and name, and include their HTML.
+//
+//	Error:  Unexpected accessibility name
+//	        element : textbox "E-mail"
+//	        expected: "Email"
+//	        actual  : "E-mail"
+//	        html    : <input type="email" id="email"></input>
+//
+// [testify]: https://github.com/stretchr/testify
+package assert
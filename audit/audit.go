@@ -0,0 +1,146 @@
+package audit
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+
+	"github.com/gost-dom/browser/dom"
+)
+
+// Violation is a failure of content to satisfy a rule.
+type Violation struct {
+	// Rule is the ID of the rule, e.g., "image-alt".
+	Rule string
+	// Element is the element violating the rule. For rules applying to the
+	// entire page, e.g., missing a main landmark, this is the root element.
+	Element dom.Element
+	Message string
+	// WCAG is the WCAG success criterion the rule helps satisfy, e.g., "1.1.1
+	// Non-text Content". It is empty for best practices.
+	WCAG string
+	// Severity of the rule, or the severity configured with [WithSeverity].
+	Severity Severity
+}
+
+func (v Violation) String() string {
+	res := fmt.Sprintf("[%s] %s", v.Rule, v.Message)
+	if v.Severity != Error {
+		res = fmt.Sprintf("%s: %s", v.Severity, res)
+	}
+	if v.WCAG != "" {
+		res += fmt.Sprintf(" (WCAG %s)", v.WCAG)
+	}
+	if v.Element != nil {
+		res += "\nElement: " + v.Element.OuterHTML()
+	}
+	return res
+}
+
+// Target is the content being audited.
+type Target struct {
+	// Elements contains the elements in scope in document order, excluding
+	// elements hidden from assistive technologies.
+	Elements []dom.Element
+	// Root is the first element in scope, e.g., the <html> element of a page,
+	// even when hidden. It is nil for an empty scope.
+	Root dom.Element
+	// Page tells if the scope is the entire document, enabling rules for the
+	// page as a whole, e.g., a page should have exactly one <h1>.
+	Page bool
+}
+
+// Rule is a check of the content.
+type Rule struct {
+	ID          string
+	Description string
+	// WCAG is the WCAG success criterion the rule helps satisfy. Empty for best
+	// practices.
+	WCAG string
+	// Severity is the default severity of violations, see also [WithSeverity].
+	Severity Severity
+	// Check returns the violations of the rule in the target. Rule, WCAG, and
+	// Severity of the returned violations are set by the audit.
+	Check func(Target) []Violation
+}
+
+// ElementRule creates a rule checking elements individually. The check
+// function returns a message describing the problem, or an empty string if the
+// element satisfies the rule.
+func ElementRule(id, wcag, description string, check func(dom.Element) string) Rule {
+	return Rule{
+		ID:          id,
+		Description: description,
+		WCAG:        wcag,
+		Check: func(t Target) []Violation {
+			var res []Violation
+			for _, e := range t.Elements {
+				if msg := check(e); msg != "" {
+					res = append(res, Violation{Element: e, Message: msg})
+				}
+			}
+			return res
+		},
+	}
+}
+
+// Run audits the content in the scope, returning the violations that are not
+// suppressed. Without options, the [DefaultRules] are used.
+func Run(s shaman.Scope, opts ...Option) []Violation {
+	return Audit(s, opts...).Violations
+}
+
+// Audit audits the content in the scope, returning a report of violations, and
+// suppressed violations separately.
+func Audit(s shaman.Scope, opts ...Option) Report {
+	c := newConfig(opts)
+	target := newTarget(s)
+	var res Report
+	for _, r := range c.rules {
+		for _, v := range r.Check(target) {
+			v.Rule = r.ID
+			v.WCAG = r.WCAG
+			v.Severity = c.severity(r)
+			if sup, ok := c.suppression(v); ok {
+				res.Suppressed = append(res.Suppressed, Suppression{v, sup.justification})
+			} else {
+				res.Violations = append(res.Violations, v)
+			}
+		}
+	}
+	return res
+}
+
+// Assert audits the content in the scope, generating an error for every
+// violation with severity [Error]. Warnings, and suppressed violations, are
+// logged.
+func Assert(t testing.TB, s shaman.Scope, opts ...Option) {
+	t.Helper()
+	report := Audit(s, opts...)
+	for _, v := range report.Suppressed {
+		t.Logf("Suppressed accessibility violation: %s", v)
+	}
+	for _, v := range report.Warnings() {
+		t.Logf("Accessibility warning: %s", v)
+	}
+	for _, v := range report.Errors() {
+		t.Errorf("Accessibility violation: %s", v)
+	}
+}
+
+func newTarget(s shaman.Scope) Target {
+	var res Target
+	all := slices.Collect(s.All())
+	if len(all) > 0 {
+		res.Root = all[0]
+		_, res.Page = all[0].ParentNode().(dom.Document)
+	}
+	for _, e := range all {
+		if !shaman.ElementHidden(e) {
+			res.Elements = append(res.Elements, e)
+		}
+	}
+	return res
+}
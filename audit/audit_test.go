@@ -0,0 +1,137 @@
+package audit_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/audit"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadHTML(t *testing.T, h string) dom.Document {
+	t.Helper()
+	win, err := html.NewWindowReader(strings.NewReader(h))
+	if err != nil {
+		t.Fatalf("Error parsing HTML document")
+	}
+	return win.Document()
+}
+
+func ruleIDs(v []audit.Violation) []string {
+	res := make([]string, len(v))
+	for i, v := range v {
+		res[i] = v.Rule
+	}
+	return res
+}
+
+func TestAuditValidPage(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `<body>
+		<header><img src="logo.png" alt="Acme" /></header>
+		<main>
+			<h1>Orders</h1>
+			<h2>Filter</h2>
+			<label>Search <input type="text" /></label>
+			<input type="hidden" name="csrf" value="x" />
+			<input type="submit" />
+			<button aria-label="Close">X</button>
+			<div role="list"><span><div role="listitem">Item</div></span></div>
+			<h3>Details</h3>
+			<h2>Summary</h2>
+		</main>
+	</body>`)
+	audit.Assert(t, shaman.NewScope(t, doc))
+}
+
+func TestAuditViolations(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `<body>
+		<h1>Orders</h1>
+		<img src="chart.png" />
+		<div role="img"></div>
+		<input type="text" />
+		<button> </button>
+		<span id="hint">Hint</span>
+		<span id="hint">Other hint</span>
+		<input aria-label="Search" aria-describedby="hint" />
+		<div role="lst"></div>
+		<div role="list"><div role="option">Item</div></div>
+		<div role="tab">Tab</div>
+		<h1>Details</h1>
+		<h3>Notes</h3>
+		<div aria-hidden="true"><img src="hidden.png" /></div>
+	</body>`)
+	violations := audit.Run(shaman.NewScope(t, doc))
+	assert.Equal(t, []string{
+		"input-name",
+		"button-name",
+		"image-alt",
+		"image-alt",
+		"duplicate-id-aria",
+		"aria-valid-role",
+		"aria-required-children",
+		"aria-required-parent",
+		"aria-required-parent",
+		"page-has-one-h1",
+		"landmark-main",
+		"heading-order",
+	}, ruleIDs(violations))
+
+	assert.Equal(t, "1.1.1 Non-text Content", violations[2].WCAG)
+	assert.Equal(t, "IMG", violations[2].Element.TagName())
+	assert.Equal(t, `Referenced id "hint" is used by 2 elements`, violations[4].Message)
+	assert.Equal(t, "Heading level 3 follows level 1", violations[11].Message)
+}
+
+func TestAuditElementScope(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `<body>
+		<h1>Orders</h1>
+		<form><h1>Search</h1><input type="text" /></form>
+	</body>`)
+	scope := shaman.NewScope(t, doc).Form()
+	// Page level rules don't apply when auditing part of the page
+	assert.Equal(t, []string{"input-name"}, ruleIDs(audit.Run(scope.Scope)))
+}
+
+func TestAuditAttributes(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `<body>
+		<span id="name">Name</span>
+		<form><input aria-labeledby="name" /></form>
+	</body>`)
+	violations := audit.Run(shaman.NewScope(t, doc).Form().Scope)
+	assert.Equal(t, []string{"input-name", "aria-valid-attr"}, ruleIDs(violations))
+	assert.Equal(t,
+		`aria-labeledby="name": unknown ARIA attribute, did you mean aria-labelledby?`,
+		violations[1].Message,
+	)
+}
+
+func TestAuditParsedFragment(t *testing.T) {
+	t.Parallel()
+	scope := shaman.ParseHTML(t, `<li><img src="avatar.png" /> Jane</li>`)
+	assert.Equal(t, []string{"image-alt"}, ruleIDs(audit.Run(scope)),
+		"Page level rules don't apply to fragments")
+}
+
+func TestAuditEdgeCases(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `<body>
+		<main>
+			<span id="it's">Hint</span>
+			<input aria-label="Search" aria-describedby="it's" />
+		</main>
+	</body>`)
+	assert.Empty(t, audit.Run(shaman.NewScope(t, doc)), "Quote in referenced id")
+
+	doc = loadHTML(t, `<html aria-hidden="true"><body><h1>Hidden</h1></body></html>`)
+	violations := audit.Run(shaman.NewScope(t, doc))
+	assert.Equal(t, []string{"landmark-main"}, ruleIDs(violations))
+	assert.Equal(t, "HTML", violations[0].Element.TagName(), "Reported against the root")
+}
@@ -0,0 +1,216 @@
+package audit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gost-dom/shaman"
+
+	"github.com/gost-dom/browser/dom"
+)
+
+// Severity tells how a violation is reported by [Assert]. Errors fail the
+// test, warnings are logged.
+type Severity int
+
+const (
+	Error Severity = iota
+	Warning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// A ViolationPredicate checks if a violation matches certain criteria, used to
+// suppress violations. Like [shaman.ElementPredicate], implementations should
+// also implement [fmt.Stringer] for better reports.
+type ViolationPredicate interface{ IsMatch(Violation) bool }
+
+// ViolationPredicateFunc wraps a function as a [ViolationPredicate].
+type ViolationPredicateFunc func(Violation) bool
+
+func (f ViolationPredicateFunc) IsMatch(v Violation) bool { return f(v) }
+
+// ByRule is a [ViolationPredicate] matching violations of the rule with the ID.
+type ByRule string
+
+func (r ByRule) IsMatch(v Violation) bool { return v.Rule == string(r) }
+
+func (r ByRule) String() string { return fmt.Sprintf("By rule: %s", string(r)) }
+
+// Within returns a [ViolationPredicate] matching violations of elements
+// matching the element predicates, or elements inside them. E.g., to match
+// violations inside a third-party widget:
+//
+//	audit.Within(shaman.ByRole(ariarole.Region), shaman.ByName("Chat"))
+func Within(opts ...shaman.ElementPredicate) ViolationPredicate { return within(opts) }
+
+type within []shaman.ElementPredicate
+
+func (w within) IsMatch(v Violation) bool {
+	for e := v.Element; e != nil; e = e.ParentElement() {
+		if w.isMatch(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w within) isMatch(e dom.Element) bool {
+	for _, p := range w {
+		if !p.IsMatch(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w within) String() string {
+	res := make([]string, len(w))
+	for i, p := range w {
+		res[i] = fmt.Sprint(p)
+	}
+	return fmt.Sprintf("Within: %s", strings.Join(res, ", "))
+}
+
+// Suppression is a violation matching a suppression, see [Suppress].
+type Suppression struct {
+	Violation
+	// Justification tells why the violation is accepted.
+	Justification string
+}
+
+func (s Suppression) String() string {
+	return fmt.Sprintf("%s\nJustification: %s", s.Violation, s.Justification)
+}
+
+// Report is the result of an audit.
+type Report struct {
+	// Violations contains violations that are not suppressed.
+	Violations []Violation
+	// Suppressed contains violations matching a suppression.
+	Suppressed []Suppression
+}
+
+// Errors returns the violations with severity [Error].
+func (r Report) Errors() []Violation { return r.withSeverity(Error) }
+
+// Warnings returns the violations with severity [Warning].
+func (r Report) Warnings() []Violation { return r.withSeverity(Warning) }
+
+func (r Report) withSeverity(s Severity) []Violation {
+	var res []Violation
+	for _, v := range r.Violations {
+		if v.Severity == s {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// Option configures an audit.
+type Option func(*config)
+
+type suppression struct {
+	preds         []ViolationPredicate
+	justification string
+}
+
+func (s suppression) isMatch(v Violation) bool {
+	for _, p := range s.preds {
+		if !p.IsMatch(v) {
+			return false
+		}
+	}
+	return true
+}
+
+type config struct {
+	rules        []Rule
+	severities   map[string]Severity
+	suppressions []suppression
+}
+
+func newConfig(opts []Option) config {
+	c := config{rules: slices.Clone(DefaultRules), severities: make(map[string]Severity)}
+	for _, o := range opts {
+		o(&c)
+	}
+	return c
+}
+
+func (c config) severity(r Rule) Severity {
+	if s, ok := c.severities[r.ID]; ok {
+		return s
+	}
+	return r.Severity
+}
+
+func (c config) suppression(v Violation) (suppression, bool) {
+	for _, s := range c.suppressions {
+		if s.isMatch(v) {
+			return s, true
+		}
+	}
+	return suppression{}, false
+}
+
+// WithRules replaces the [DefaultRules] with the rules.
+func WithRules(rules ...Rule) Option {
+	return func(c *config) { c.rules = slices.Clone(rules) }
+}
+
+// Enable adds rules to the audit, e.g., custom rules. An enabled rule replaces
+// a rule with the same ID.
+func Enable(rules ...Rule) Option {
+	return func(c *config) {
+		for _, r := range rules {
+			if i := slices.IndexFunc(c.rules, hasID(r.ID)); i >= 0 {
+				c.rules[i] = r
+			} else {
+				c.rules = append(c.rules, r)
+			}
+		}
+	}
+}
+
+// Disable removes the rules with the IDs from the audit.
+func Disable(ids ...string) Option {
+	return func(c *config) {
+		for _, id := range ids {
+			c.rules = slices.DeleteFunc(c.rules, hasID(id))
+		}
+	}
+}
+
+// WithSeverity overrides the severity of the rule with the ID.
+func WithSeverity(id string, s Severity) Option {
+	return func(c *config) { c.severities[id] = s }
+}
+
+// Suppress accepts violations matching all the predicates. The justification
+// explains why, e.g., "Legacy third-party chat widget", and is included in the
+// report of suppressed violations.
+//
+//	audit.Assert(t, scope, audit.Suppress(
+//		"Legacy third-party chat widget",
+//		audit.ByRule("button-name"),
+//		audit.Within(shaman.ByRole(ariarole.Region), shaman.ByName("Chat")),
+//	))
+func Suppress(justification string, preds ...ViolationPredicate) Option {
+	return func(c *config) {
+		c.suppressions = append(c.suppressions, suppression{preds, justification})
+	}
+}
+
+func hasID(id string) func(Rule) bool {
+	return func(r Rule) bool { return r.ID == id }
+}
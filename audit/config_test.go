@@ -0,0 +1,92 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/audit"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+const legacyPage = `<body>
+	<main>
+		<h1>Orders</h1>
+		<h3>Open orders</h3>
+		<input type="text" />
+		<section aria-label="Chat">
+			<button></button>
+			<img src="avatar.png" />
+		</section>
+	</main>
+</body>`
+
+func TestAuditDisableRules(t *testing.T) {
+	t.Parallel()
+	scope := shaman.NewScope(t, loadHTML(t, legacyPage))
+	assert.Equal(t,
+		[]string{"input-name", "button-name"},
+		ruleIDs(audit.Run(scope, audit.Disable("image-alt", "heading-order"))),
+	)
+	assert.Equal(t,
+		[]string{"image-alt"},
+		ruleIDs(audit.Run(scope, audit.WithRules(audit.ImageAlt))),
+	)
+}
+
+func TestAuditCustomRule(t *testing.T) {
+	t.Parallel()
+	scope := shaman.NewScope(t, loadHTML(t, legacyPage))
+	noSections := audit.ElementRule("no-sections", "", "Don't use sections",
+		func(e dom.Element) string {
+			if e.TagName() == "SECTION" {
+				return "Section used"
+			}
+			return ""
+		},
+	)
+	report := audit.Audit(scope, audit.WithRules(noSections))
+	assert.Equal(t, []string{"no-sections"}, ruleIDs(report.Violations))
+
+	report = audit.Audit(scope, audit.Enable(noSections))
+	assert.Equal(t, "no-sections", report.Violations[len(report.Violations)-1].Rule)
+}
+
+func TestAuditSeverity(t *testing.T) {
+	t.Parallel()
+	scope := shaman.NewScope(t, loadHTML(t, legacyPage))
+	report := audit.Audit(scope, audit.WithSeverity("heading-order", audit.Warning))
+	assert.Equal(t, []string{"heading-order"}, ruleIDs(report.Warnings()))
+	assert.Equal(t,
+		[]string{"input-name", "button-name", "image-alt"},
+		ruleIDs(report.Errors()),
+	)
+	assert.Contains(t, report.Warnings()[0].String(), "warning: [heading-order]")
+}
+
+func TestAuditSuppress(t *testing.T) {
+	t.Parallel()
+	scope := shaman.NewScope(t, loadHTML(t, legacyPage))
+	report := audit.Audit(scope,
+		audit.Suppress("Legacy third-party chat widget",
+			audit.Within(shaman.ByRole(ariarole.Region), shaman.ByName("Chat")),
+		),
+		audit.Suppress("Redesign pending", audit.ByRule("heading-order")),
+	)
+	assert.Equal(t, []string{"input-name"}, ruleIDs(report.Violations))
+	if assert.Len(t, report.Suppressed, 3) {
+		assert.Equal(t, "button-name", report.Suppressed[0].Rule)
+		assert.Equal(t, "Legacy third-party chat widget", report.Suppressed[0].Justification)
+		assert.Equal(t, "Redesign pending", report.Suppressed[2].Justification)
+	}
+
+	audit.Assert(t, scope,
+		audit.Disable("input-name"),
+		audit.Suppress("Legacy third-party chat widget",
+			audit.Within(shaman.ByRole(ariarole.Region), shaman.ByName("Chat")),
+		),
+		audit.WithSeverity("heading-order", audit.Warning),
+	)
+}
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+
+	"github.com/gost-dom/browser/dom"
+)
+
+// Continuous returns a [shaman.Auditor] for [shaman.WithContinuousAudit],
+// auditing the page after every interaction:
+//
+//	scope := shaman.WindowScope(t, win, shaman.WithContinuousAudit(
+//		audit.Continuous(audit.Disable("heading-order")),
+//	))
+//
+// A violation generates an error once, naming the interaction that introduced
+// it, e.g., `click on button "Load more"`. Warnings, and suppressed violations,
+// are logged once.
+func Continuous(opts ...Option) shaman.Auditor {
+	reported := make(map[violationKey]bool)
+	return func(t testing.TB, s shaman.Scope, step string) {
+		t.Helper()
+		report := Audit(s, opts...)
+		seen := func(v Violation) bool {
+			key := violationKey{v.Rule, v.Element, v.Message}
+			res := reported[key]
+			reported[key] = true
+			return res
+		}
+		report.Violations = slices.DeleteFunc(report.Violations, seen)
+		report.Suppressed = slices.DeleteFunc(report.Suppressed, func(s Suppression) bool {
+			return seen(s.Violation)
+		})
+		for _, v := range report.Suppressed {
+			t.Logf("Suppressed accessibility violation after %s: %s", step, v)
+		}
+		for _, v := range report.Warnings() {
+			t.Logf("Accessibility warning after %s: %s", step, v)
+		}
+		for _, v := range report.Errors() {
+			t.Errorf("Accessibility violation after %s: %s", step, v)
+		}
+	}
+}
+
+// violationKey identifies a violation that was already reported.
+type violationKey struct {
+	rule    string
+	element dom.Element
+	message string
+}
@@ -0,0 +1,44 @@
+package audit_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/audit"
+	"github.com/gost-dom/shaman/internal/testtb"
+
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContinuousAudit(t *testing.T) {
+	t.Parallel()
+	win, err := html.NewWindowReader(strings.NewReader(`<body><main>
+		<h1>Orders</h1>
+		<button>Load more</button>
+		<div id="results"></div>
+	</main></body>`))
+	assert.NoError(t, err)
+	rec := &testtb.RecordingTB{TB: t}
+	scope := shaman.WindowScope(rec, win, shaman.WithContinuousAudit(audit.Continuous()))
+	assert.Empty(t, rec.Errors)
+
+	button := scope.Get(shaman.ByRole(ariarole.Button))
+	button.AddEventListener("click", event.NewEventHandlerFuncWithoutError(
+		func(*event.Event) {
+			img := win.Document().CreateElement("img")
+			win.Document().GetElementById("results").AppendChild(img)
+		},
+	))
+	button.Click()
+	scope.Get(shaman.ByRole(ariarole.Button)).Click()
+	scope.Get(shaman.ByRole(ariarole.Button))
+
+	if assert.Len(t, rec.Errors, 2, "Each violation is reported once") {
+		assert.Contains(t, rec.Errors[0], `after click on button "Load more": [image-alt]`)
+		assert.Contains(t, rec.Errors[1], `after click on button "Load more": [image-alt]`)
+	}
+}
@@ -0,0 +1,11 @@
+// Package audit checks content for common accessibility problems, such as
+// form fields and buttons without accessibility names, images without alt
+// text, and invalid ARIA roles.
+//
+// Add a one-line check of the page to a test:
+//
+//	audit.Assert(t, shaman.WindowScope(t, win))
+//
+// The rules catch problems that can be detected from the DOM alone. Passing an
+// audit doesn't guarantee that content is accessible.
+package audit
@@ -0,0 +1,443 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+)
+
+// DefaultRules are the rules used by [Run] and [Assert].
+var DefaultRules = []Rule{
+	InputName,
+	ButtonName,
+	ImageAlt,
+	DuplicateIDAria,
+	ValidRole,
+	ValidAttributes,
+	RequiredChildren,
+	RequiredParent,
+	PageHasOneH1,
+	LandmarkMain,
+	HeadingOrder,
+}
+
+// InputName checks that form fields have an accessibility name, e.g., from an
+// associated <label>.
+var InputName = ElementRule(
+	"input-name",
+	"4.1.2 Name, Role, Value",
+	"Form fields must have an accessibility name",
+	func(e dom.Element) string {
+		role := ariarole.GetElementRole(e)
+		if !role.FormField() || buttonInput(e) || name(e) != "" {
+			return ""
+		}
+		return fmt.Sprintf("Form field (%s) has no accessibility name", role)
+	},
+)
+
+// ButtonName checks that buttons have an accessibility name.
+var ButtonName = ElementRule(
+	"button-name",
+	"4.1.2 Name, Role, Value",
+	"Buttons must have an accessibility name",
+	func(e dom.Element) string {
+		if ariarole.GetElementRole(e) != ariarole.Button || buttonName(e) != "" {
+			return ""
+		}
+		return "Button has no accessibility name"
+	},
+)
+
+// ImageAlt checks that images have alternative text. Decorative images can use
+// an empty alt text, alt="".
+var ImageAlt = ElementRule(
+	"image-alt",
+	"1.1.1 Non-text Content",
+	"Images must have alternative text",
+	func(e dom.Element) string {
+		if e.TagName() == "IMG" {
+			if _, ok := e.GetAttribute("alt"); !ok && name(e) == "" {
+				return "Image has no alt attribute"
+			}
+			return ""
+		}
+		if ariarole.GetElementRole(e) == ariarole.Img && name(e) == "" {
+			return "Element with role img has no accessibility name"
+		}
+		return ""
+	},
+)
+
+// DuplicateIDAria checks that ids referenced by aria attributes, e.g.,
+// aria-labelledby, are unique.
+var DuplicateIDAria = Rule{
+	ID:          "duplicate-id-aria",
+	Description: "IDs referenced by ARIA attributes must be unique",
+	WCAG:        "4.1.2 Name, Role, Value",
+	Check: func(t Target) []Violation {
+		var res []Violation
+		var ids map[string]int
+		reported := make(map[string]bool)
+		for _, e := range t.Elements {
+			for _, id := range idRefs(e) {
+				if reported[id] {
+					continue
+				}
+				if ids == nil {
+					ids = idCounts(e.OwnerDocument())
+				}
+				if n := ids[id]; n > 1 {
+					reported[id] = true
+					res = append(res, Violation{
+						Element: e,
+						Message: fmt.Sprintf("Referenced id %q is used by %d elements", id, n),
+					})
+				}
+			}
+		}
+		return res
+	},
+}
+
+// ValidRole checks that role attributes contain a role defined by WAI-ARIA.
+var ValidRole = ElementRule(
+	"aria-valid-role",
+	"4.1.2 Name, Role, Value",
+	"Role attributes must contain a valid role",
+	func(e dom.Element) string {
+		if r, ok := e.GetAttribute("role"); ok && !ariarole.Role(r).Valid() {
+			return fmt.Sprintf("Invalid role: %q", r)
+		}
+		return ""
+	},
+)
+
+// ValidAttributes checks that aria-* attributes are defined by WAI-ARIA, have
+// valid values, are permitted on the role of the element, and that ID
+// references resolve. See also [ariarole.ValidateElement].
+var ValidAttributes = Rule{
+	ID:          "aria-valid-attr",
+	Description: "ARIA attributes must be valid, and permitted on the role",
+	WCAG:        "4.1.2 Name, Role, Value",
+	Check: func(t Target) []Violation {
+		var res []Violation
+		for _, e := range t.Elements {
+			for _, err := range ariarole.ValidateElement(e) {
+				res = append(res, Violation{Element: e, Message: err.Error()})
+			}
+		}
+		return res
+	},
+}
+
+// RequiredChildren checks that elements with an explicit role, e.g.,
+// role="list", contain the elements they must own, e.g., role="listitem".
+var RequiredChildren = ElementRule(
+	"aria-required-children",
+	"1.3.1 Info and Relationships",
+	"Elements with a role must contain their required children",
+	func(e dom.Element) string {
+		r, ok := e.GetAttribute("role")
+		if !ok {
+			return ""
+		}
+		required := requiredChildren[ariarole.Role(r)]
+		if required == nil {
+			return ""
+		}
+		owned := ownedElements(e)
+		for _, c := range owned {
+			if role := ariarole.GetElementRole(c); !required[role] {
+				return fmt.Sprintf("Role %s cannot own an element with role %q", r, role)
+			}
+		}
+		if len(owned) == 0 && !e.HasAttribute("aria-busy") {
+			return fmt.Sprintf("Role %s has no required children (%s)", r, roleList(required))
+		}
+		return ""
+	},
+)
+
+// RequiredParent checks that elements with an explicit role, e.g.,
+// role="listitem", are owned by an element with a required role, e.g.,
+// role="list".
+var RequiredParent = ElementRule(
+	"aria-required-parent",
+	"1.3.1 Info and Relationships",
+	"Elements with a role must be contained in their required parent",
+	func(e dom.Element) string {
+		r, ok := e.GetAttribute("role")
+		if !ok {
+			return ""
+		}
+		required := requiredParents[ariarole.Role(r)]
+		if required == nil {
+			return ""
+		}
+		if p := owner(e); p == nil || !required[ariarole.GetElementRole(p)] {
+			return fmt.Sprintf("Role %s must be contained in %s", r, roleList(required))
+		}
+		return ""
+	},
+)
+
+// PageHasOneH1 checks that a page has at most one level 1 heading.
+var PageHasOneH1 = Rule{
+	ID:          "page-has-one-h1",
+	Description: "A page should have at most one level 1 heading",
+	Check: func(t Target) []Violation {
+		if !t.Page {
+			return nil
+		}
+		var res []Violation
+		var first bool
+		for _, e := range headings(t) {
+			if ariarole.HeadingLevel(e) != 1 {
+				continue
+			}
+			if first {
+				res = append(res, Violation{
+					Element: e,
+					Message: "Page has more than one level 1 heading",
+				})
+			}
+			first = true
+		}
+		return res
+	},
+}
+
+// LandmarkMain checks that a page has a main landmark.
+var LandmarkMain = Rule{
+	ID:          "landmark-main",
+	Description: "A page must have a main landmark",
+	WCAG:        "2.4.1 Bypass Blocks",
+	Check: func(t Target) []Violation {
+		if !t.Page || t.Root == nil {
+			return nil
+		}
+		for _, e := range t.Elements {
+			if ariarole.GetElementRole(e) == ariarole.Main {
+				return nil
+			}
+		}
+		return []Violation{{Element: t.Root, Message: "Page has no main landmark"}}
+	},
+}
+
+// HeadingOrder checks that heading levels only increase by one, e.g., an <h2>
+// followed by an <h4> skips a level.
+var HeadingOrder = Rule{
+	ID:          "heading-order",
+	Description: "Heading levels should only increase by one",
+	Check: func(t Target) []Violation {
+		if !t.Page {
+			return nil
+		}
+		var res []Violation
+		var prev int
+		for _, e := range headings(t) {
+			level := ariarole.HeadingLevel(e)
+			if prev > 0 && level > prev+1 {
+				res = append(res, Violation{
+					Element: e,
+					Message: fmt.Sprintf("Heading level %d follows level %d", level, prev),
+				})
+			}
+			prev = level
+		}
+		return res
+	},
+}
+
+// name returns the accessibility name of e, without surrounding whitespace.
+func name(e dom.Element) string { return strings.TrimSpace(shaman.ElementName(e)) }
+
+// buttonInput returns whether e is an <input> rendered as a button.
+func buttonInput(e dom.Element) bool {
+	if e.TagName() != "INPUT" {
+		return false
+	}
+	switch t, _ := e.GetAttribute("type"); t {
+	case "button", "submit", "reset", "image":
+		return true
+	}
+	return false
+}
+
+// buttonName returns the name of a button, including the value, or the
+// default label of an <input> button.
+func buttonName(e dom.Element) string {
+	if n := name(e); n != "" || !buttonInput(e) {
+		return n
+	}
+	t, _ := e.GetAttribute("type")
+	if t == "image" {
+		alt, _ := e.GetAttribute("alt")
+		return alt
+	}
+	if v, ok := e.GetAttribute("value"); ok {
+		return v
+	}
+	switch t {
+	case "submit":
+		return "Submit"
+	case "reset":
+		return "Reset"
+	}
+	return ""
+}
+
+var idRefAttributes = []string{
+	"aria-activedescendant",
+	"aria-controls",
+	"aria-describedby",
+	"aria-details",
+	"aria-errormessage",
+	"aria-flowto",
+	"aria-labelledby",
+	"aria-owns",
+}
+
+// idCounts returns the number of elements of the document with each id.
+func idCounts(doc dom.Document) map[string]int {
+	res := make(map[string]int)
+	for e := range shaman.NewScope(nil, doc).All() {
+		if id, ok := e.GetAttribute("id"); ok {
+			res[id]++
+		}
+	}
+	return res
+}
+
+// idRefs returns the ids referenced by aria attributes of e.
+func idRefs(e dom.Element) []string {
+	var res []string
+	for _, a := range idRefAttributes {
+		if v, ok := e.GetAttribute(a); ok {
+			res = append(res, strings.Fields(v)...)
+		}
+	}
+	return res
+}
+
+var requiredChildren = map[ariarole.Role]map[ariarole.Role]bool{
+	ariarole.List:       roles(ariarole.Listitem),
+	ariarole.Listbox:    roles(ariarole.Option, ariarole.Group),
+	ariarole.Radiogroup: roles(ariarole.Radio),
+	ariarole.Tablist:    roles(ariarole.Tab),
+	ariarole.Tree:       roles(ariarole.Treeitem, ariarole.Group),
+	ariarole.Menu: roles(
+		ariarole.Menuitem, ariarole.Menuitemcheckbox, ariarole.Menuitemradio, ariarole.Group,
+	),
+	ariarole.Menubar: roles(
+		ariarole.Menuitem, ariarole.Menuitemcheckbox, ariarole.Menuitemradio, ariarole.Group,
+	),
+	ariarole.Table:    roles(ariarole.Row, ariarole.Rowgroup, ariarole.Caption),
+	ariarole.Grid:     roles(ariarole.Row, ariarole.Rowgroup, ariarole.Caption),
+	ariarole.Treegrid: roles(ariarole.Row, ariarole.Rowgroup, ariarole.Caption),
+	ariarole.Rowgroup: roles(ariarole.Row),
+	ariarole.Row: roles(
+		ariarole.Cell, ariarole.Gridcell, ariarole.Columnheader, ariarole.Rowheader,
+	),
+}
+
+var requiredParents = map[ariarole.Role]map[ariarole.Role]bool{
+	ariarole.Listitem: roles(ariarole.List),
+	ariarole.Option:   roles(ariarole.Listbox, ariarole.Group),
+	ariarole.Tab:      roles(ariarole.Tablist),
+	ariarole.Treeitem: roles(ariarole.Tree, ariarole.Group),
+	ariarole.Menuitem: roles(ariarole.Menu, ariarole.Menubar, ariarole.Group),
+	ariarole.Menuitemcheckbox: roles(
+		ariarole.Menu, ariarole.Menubar, ariarole.Group,
+	),
+	ariarole.Menuitemradio: roles(ariarole.Menu, ariarole.Menubar, ariarole.Group),
+	ariarole.Row: roles(
+		ariarole.Table, ariarole.Grid, ariarole.Treegrid, ariarole.Rowgroup,
+	),
+	ariarole.Rowgroup:     roles(ariarole.Table, ariarole.Grid, ariarole.Treegrid),
+	ariarole.Cell:         roles(ariarole.Row),
+	ariarole.Gridcell:     roles(ariarole.Row),
+	ariarole.Columnheader: roles(ariarole.Row),
+	ariarole.Rowheader:    roles(ariarole.Row),
+}
+
+func roles(r ...ariarole.Role) map[ariarole.Role]bool {
+	res := make(map[ariarole.Role]bool, len(r))
+	for _, role := range r {
+		res[role] = true
+	}
+	return res
+}
+
+// roleList formats a set of roles in the order they are defined in the role
+// tables, for stable messages.
+func roleList(m map[ariarole.Role]bool) string {
+	var res []string
+	for _, r := range []ariarole.Role{
+		ariarole.List, ariarole.Listitem, ariarole.Listbox, ariarole.Option,
+		ariarole.Radiogroup, ariarole.Radio, ariarole.Tablist, ariarole.Tab,
+		ariarole.Tree, ariarole.Treeitem, ariarole.Menu, ariarole.Menubar,
+		ariarole.Menuitem, ariarole.Menuitemcheckbox, ariarole.Menuitemradio,
+		ariarole.Table, ariarole.Grid, ariarole.Treegrid, ariarole.Rowgroup,
+		ariarole.Row, ariarole.Caption, ariarole.Cell, ariarole.Gridcell,
+		ariarole.Columnheader, ariarole.Rowheader, ariarole.Group,
+	} {
+		if m[r] {
+			res = append(res, string(r))
+		}
+	}
+	return strings.Join(res, ", ")
+}
+
+// transparent returns whether an element is ignored when finding the owned
+// elements, or owner, of an element; i.e., elements without a role.
+func transparent(e dom.Element) bool {
+	switch ariarole.GetElementRole(e) {
+	case ariarole.None, "none", "presentation", "generic":
+		return true
+	}
+	return false
+}
+
+// ownedElements returns the elements owned by e, i.e., the closest descendants
+// with a role.
+func ownedElements(e dom.Element) []dom.Element {
+	var res []dom.Element
+	for _, c := range e.Children().All() {
+		if shaman.ElementHidden(c) {
+			continue
+		}
+		if transparent(c) {
+			res = append(res, ownedElements(c)...)
+		} else {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// owner returns the closest ancestor of e with a role.
+func owner(e dom.Element) dom.Element {
+	for p := e.ParentElement(); p != nil; p = p.ParentElement() {
+		if !transparent(p) {
+			return p
+		}
+	}
+	return nil
+}
+
+func headings(t Target) []dom.Element {
+	var res []dom.Element
+	for _, e := range t.Elements {
+		if ariarole.GetElementRole(e) == ariarole.Heading {
+			res = append(res, e)
+		}
+	}
+	return res
+}
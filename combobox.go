@@ -0,0 +1,314 @@
+package shaman
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+// Combobox finds the element with the role "combobox" that matches the
+// options. This is the role of a single-selection <select> element, and custom
+// comboboxes, e.g., <input role="combobox" aria-controls="popup-id">.
+func (s Scope) Combobox(opts ...ElementPredicate) ComboboxRole {
+	s.t.Helper()
+	opts = append(opts, ByRole(ariarole.Combobox))
+	return ComboboxRole{s.Get(opts...), s.t}
+}
+
+// Listbox finds the element with the role "listbox" that matches the options.
+// This is the role of a <select multiple> element, and custom listboxes, e.g.,
+// <ul role="listbox">.
+func (s Scope) Listbox(opts ...ElementPredicate) ListboxRole {
+	s.t.Helper()
+	opts = append(opts, ByRole(ariarole.Listbox))
+	return ListboxRole{s.Get(opts...), s.t}
+}
+
+// ComboboxRole is a helper to interact with a [combobox]; an input field with
+// a popup, typically a list of options to choose from.
+//
+// For a native <select> element, options are the <option> elements. For a
+// custom combobox, options are the elements with the role "option" in the
+// popup referenced by aria-controls.
+//
+// [combobox]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Reference/Roles/combobox_role
+type ComboboxRole struct {
+	html.HTMLElement
+	t testing.TB
+}
+
+func (cb ComboboxRole) unwrap() html.HTMLElement { return cb.HTMLElement }
+
+func (cb ComboboxRole) native() bool { return cb.TagName() == "SELECT" }
+
+// popup returns the element containing the options. Returns the element itself
+// when there is no popup, e.g., a native <select>.
+func (cb ComboboxRole) popup() dom.Element {
+	if id, ok := cb.GetAttribute("aria-controls"); ok {
+		if popup := cb.OwnerDocument().GetElementById(id); popup != nil {
+			return popup
+		}
+	}
+	return cb.HTMLElement
+}
+
+// Options returns the options of the combobox. For a custom combobox, the
+// popup may need to be opened before the options exist in the DOM.
+func (cb ComboboxRole) Options() []html.HTMLElement { return options(cb.t, cb.popup()) }
+
+// Selected returns the selected option, or nil if no option is selected. As in
+// a browser, a native <select> without an explicitly selected option has the
+// first option selected.
+func (cb ComboboxRole) Selected() html.HTMLElement {
+	opts := cb.Options()
+	if selected := selectedOptions(opts); len(selected) > 0 {
+		return selected[0]
+	}
+	if cb.native() && len(opts) > 0 {
+		return opts[0]
+	}
+	return nil
+}
+
+// Active returns the option referenced by aria-activedescendant, i.e., the
+// option that has visual focus while DOM focus remains on the combobox. Returns
+// nil if there is no active option.
+func (cb ComboboxRole) Active() html.HTMLElement {
+	if id, ok := cb.GetAttribute("aria-activedescendant"); ok && id != "" {
+		if e, ok := cb.OwnerDocument().GetElementById(id).(html.HTMLElement); ok {
+			return e
+		}
+	}
+	return nil
+}
+
+// Expanded returns whether the popup of a custom combobox is displayed, as
+// indicated by aria-expanded.
+func (cb ComboboxRole) Expanded() bool {
+	v, _ := cb.GetAttribute("aria-expanded")
+	return v == "true"
+}
+
+// Open displays the popup of a custom combobox by clicking it; or pressing the
+// down arrow if clicking didn't expand it. Open has no effect on a native
+// <select>, as the options are always part of the DOM.
+func (cb ComboboxRole) Open() {
+	if cb.native() || cb.Expanded() {
+		return
+	}
+	cb.Click()
+	if !cb.Expanded() {
+		pressKey(cb.HTMLElement, "ArrowDown")
+	}
+}
+
+// Close closes the popup of a custom combobox by pressing escape.
+func (cb ComboboxRole) Close() {
+	if cb.native() || !cb.Expanded() {
+		return
+	}
+	pressKey(cb.HTMLElement, "Escape")
+}
+
+// Select chooses the option with the accessibility name. For a native <select>,
+// the option is selected and "input" and "change" events are dispatched. For a
+// custom combobox, the popup is opened, and the option is clicked.
+func (cb ComboboxRole) Select(name string) {
+	cb.t.Helper()
+	if cb.native() {
+		selectNativeOptions(cb.t, cb.HTMLElement, cb.Options(), name)
+		return
+	}
+	cb.Open()
+	getOption(cb.t, cb.Options(), name).Click()
+}
+
+// Filter types text into the input field of an autocomplete combobox, e.g.,
+// a combobox fetching options from a search endpoint on input. The text is
+// appended to the current value, one key at a time.
+func (cb ComboboxRole) Filter(text string) {
+	cb.t.Helper()
+	input, ok := cb.HTMLElement.(html.HTMLInputElement)
+	if !ok {
+		// ARIA 1.1 pattern: The combobox contains the textbox.
+		input, ok = NewScope(cb.t, cb.HTMLElement).
+			Find(ByRole(ariarole.Textbox)).(html.HTMLInputElement)
+	}
+	if !ok {
+		cb.t.Fatalf("Combobox has no input field to filter by: %s", cb.OuterHTML())
+		return
+	}
+	input.Focus()
+	typeText(input, text)
+}
+
+// ListboxRole is a helper to interact with a [listbox]; a list of options to
+// choose one or more from.
+//
+// [listbox]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Reference/Roles/listbox_role
+type ListboxRole struct {
+	html.HTMLElement
+	t testing.TB
+}
+
+func (lb ListboxRole) unwrap() html.HTMLElement { return lb.HTMLElement }
+
+func (lb ListboxRole) native() bool { return lb.TagName() == "SELECT" }
+
+func (lb ListboxRole) multiselectable() bool {
+	if lb.native() {
+		return lb.HasAttribute("multiple")
+	}
+	v, _ := lb.GetAttribute("aria-multiselectable")
+	return v == "true"
+}
+
+// Options returns the options of the listbox.
+func (lb ListboxRole) Options() []html.HTMLElement { return options(lb.t, lb.HTMLElement) }
+
+// Selected returns all selected options.
+func (lb ListboxRole) Selected() []html.HTMLElement { return selectedOptions(lb.Options()) }
+
+// Select chooses a single option by its accessibility name. For a native
+// <select>, other options are deselected.
+func (lb ListboxRole) Select(name string) {
+	lb.t.Helper()
+	lb.SelectMany(name)
+}
+
+// SelectMany chooses the options with the accessibility names. Selecting more
+// than one option fails the test unless the listbox is multiselectable.
+//
+// For a native <select>, options not in names are deselected. For a custom
+// multiselectable listbox, each option where the selected state differs from
+// the desired state is clicked; for a single-selection listbox, the option is
+// clicked unless already selected.
+func (lb ListboxRole) SelectMany(names ...string) {
+	lb.t.Helper()
+	if len(names) > 1 && !lb.multiselectable() {
+		lb.t.Fatalf("Listbox is not multiselectable: %s", lb.OuterHTML())
+		return
+	}
+	opts := lb.Options()
+	if lb.native() {
+		selectNativeOptions(lb.t, lb.HTMLElement, opts, names...)
+		return
+	}
+	for _, name := range names {
+		if o := getOption(lb.t, opts, name); !lb.multiselectable() && !isSelected(o) {
+			o.Click()
+		}
+	}
+	if !lb.multiselectable() {
+		return
+	}
+	for _, o := range opts {
+		if slices.Contains(names, ElementName(o)) != isSelected(o) {
+			o.Click()
+		}
+	}
+}
+
+// options returns all elements with the role "option" in container.
+func options(t testing.TB, container dom.Element) []html.HTMLElement {
+	var res []html.HTMLElement
+	for o := range NewScope(t, container).FindAll(ByRole(ariarole.Option)) {
+		if o, ok := o.(html.HTMLElement); ok {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+func selectedOptions(opts []html.HTMLElement) []html.HTMLElement {
+	var res []html.HTMLElement
+	for _, o := range opts {
+		if isSelected(o) {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+// ElementSelectedOptions returns the selected options of a listbox or a
+// combobox, e.g., a <select> element. As in a browser, a single-selection
+// <select> without an explicitly selected option has the first enabled option
+// selected.
+func ElementSelectedOptions(e dom.Element) []html.HTMLElement {
+	e = unwrapElement(e)
+	opts := options(nil, e)
+	selected := selectedOptions(opts)
+	if len(selected) > 0 || e.TagName() != "SELECT" || e.HasAttribute("multiple") {
+		return selected
+	}
+	for _, o := range opts {
+		if !ElementDisabled(o) {
+			return []html.HTMLElement{o}
+		}
+	}
+	return nil
+}
+
+// isSelected returns whether an option is selected. For a native <option>, this
+// is the selected content attribute, otherwise aria-selected.
+func isSelected(o dom.Element) bool {
+	if o.TagName() == "OPTION" {
+		return o.HasAttribute("selected")
+	}
+	v, _ := o.GetAttribute("aria-selected")
+	return v == "true"
+}
+
+// getOption returns the option with the accessibility name, failing the test
+// if no option is found.
+func getOption(t testing.TB, opts []html.HTMLElement, name string) html.HTMLElement {
+	t.Helper()
+	for _, o := range opts {
+		if ElementName(o) == name {
+			return o
+		}
+	}
+	names := make([]string, len(opts))
+	for i, o := range opts {
+		names[i] = ElementName(o)
+	}
+	t.Fatalf("No option named %q. Options: %s", name, strings.Join(names, ", "))
+	return nil
+}
+
+// selectNativeOptions selects the named <option> elements of a <select>,
+// deselecting all others, and dispatches "input" and "change" events if the
+// selection changed.
+func selectNativeOptions(
+	t testing.TB,
+	sel dom.Element,
+	opts []html.HTMLElement,
+	names ...string,
+) {
+	t.Helper()
+	for _, name := range names {
+		getOption(t, opts, name)
+	}
+	changed := false
+	for _, o := range opts {
+		want := slices.Contains(names, ElementName(o))
+		if want == isSelected(o) {
+			continue
+		}
+		changed = true
+		if want {
+			o.SetAttribute("selected", "")
+		} else {
+			o.RemoveAttribute("selected")
+		}
+	}
+	if changed {
+		dispatchEvent(sel, "input")
+		dispatchEvent(sel, "change")
+	}
+}
@@ -0,0 +1,140 @@
+package shaman_test
+
+import (
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func optionNames(opts []html.HTMLElement) []string {
+	res := make([]string, len(opts))
+	for i, o := range opts {
+		res[i] = ElementName(o)
+	}
+	return res
+}
+
+func selectedOptions(opts []html.HTMLElement) []html.HTMLElement {
+	var res []html.HTMLElement
+	for _, o := range opts {
+		if o.HasAttribute("selected") {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+func TestComboboxNativeSelect(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<label for="country">Country</label>
+		<select id="country">
+			<option>Denmark</option>
+			<option>Sweden</option>
+			<option>Norway</option>
+		</select>`)
+	var events []string
+	doc.GetElementById("country").AddEventListener("change",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			events = append(events, e.Type)
+		}))
+
+	cb := NewScope(t, doc).Combobox(ByName("Country"))
+	assert.Equal(t, []string{"Denmark", "Sweden", "Norway"}, optionNames(cb.Options()))
+	assert.Equal(t, "Denmark", ElementName(cb.Selected()), "First option selected by default")
+
+	cb.Select("Sweden")
+	assert.Equal(t, "Sweden", ElementName(cb.Selected()))
+	assert.Equal(t, []string{"change"}, events)
+
+	cb.Select("Norway")
+	assert.Equal(t, "Norway", ElementName(cb.Selected()))
+	assert.Equal(t, []string{"Norway"}, optionNames(selectedOptions(cb.Options())))
+}
+
+func TestComboboxCustom(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<input id="cb" type="text" role="combobox" aria-label="Fruit"
+			aria-expanded="false" aria-controls="fruits" />
+		<ul id="fruits" role="listbox">
+			<li id="apple" role="option">Apple</li>
+			<li id="banana" role="option">Banana</li>
+		</ul>`)
+	input := doc.GetElementById("cb")
+	input.AddEventListener("click",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			input.SetAttribute("aria-expanded", "true")
+		}))
+	input.AddEventListener("keydown",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			if e.Data.(KeyboardEventInit).Key == "Escape" {
+				input.SetAttribute("aria-expanded", "false")
+			}
+		}))
+	for _, o := range []string{"apple", "banana"} {
+		opt := doc.GetElementById(o)
+		opt.AddEventListener("click",
+			event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+				opt.SetAttribute("aria-selected", "true")
+				input.SetAttribute("aria-activedescendant", o)
+			}))
+	}
+
+	cb := NewScope(t, doc).Combobox(ByName("Fruit"))
+	assert.Nil(t, cb.Selected())
+	cb.Select("Banana")
+	assert.True(t, cb.Expanded(), "Combobox opened when selecting")
+	assert.Equal(t, "Banana", ElementName(cb.Selected()))
+	assert.Equal(t, "Banana", ElementName(cb.Active()))
+
+	cb.Close()
+	assert.False(t, cb.Expanded(), "Combobox closed")
+
+	cb.Filter("Ba")
+	assert.Equal(t, "Ba", cb.HTMLElement.(html.HTMLInputElement).Value())
+}
+
+func TestListbox(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Native <select multiple>", func(t *testing.T) {
+		doc := loadHTML(t, `
+			<label for="tags">Tags</label>
+			<select id="tags" multiple>
+				<option selected>Go</option>
+				<option>HTMX</option>
+				<option>Testing</option>
+			</select>`)
+		lb := NewScope(t, doc).Listbox(ByName("Tags"))
+		lb.SelectMany("HTMX", "Testing")
+		assert.Equal(t, []string{"HTMX", "Testing"}, optionNames(lb.Selected()))
+	})
+
+	t.Run("Custom multiselectable listbox", func(t *testing.T) {
+		doc := loadHTML(t, `
+			<ul role="listbox" aria-label="Tags" aria-multiselectable="true">
+				<li role="option" aria-selected="true">Go</li>
+				<li role="option" aria-selected="false">HTMX</li>
+				<li role="option" aria-selected="false">Testing</li>
+			</ul>`)
+		lb := NewScope(t, doc).Listbox(ByName("Tags"))
+		for _, o := range lb.Options() {
+			o.AddEventListener("click",
+				event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+					v, _ := o.GetAttribute("aria-selected")
+					if v == "true" {
+						o.SetAttribute("aria-selected", "false")
+					} else {
+						o.SetAttribute("aria-selected", "true")
+					}
+				}))
+		}
+		lb.SelectMany("HTMX", "Testing")
+		assert.Equal(t, []string{"HTMX", "Testing"}, optionNames(lb.Selected()))
+	})
+}
@@ -0,0 +1,132 @@
+package shaman
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+)
+
+// An Auditor checks the content of a scope, e.g., for accessibility problems,
+// generating errors on t. The step describes the interaction that changed the
+// content, e.g., `click on button "Save"`.
+//
+// See also [WithContinuousAudit], and the audit package for an implementation.
+type Auditor func(t testing.TB, s Scope, step string)
+
+// WindowScopeOption configures a scope created by [WindowScope].
+type WindowScopeOption func(*windowContainerer)
+
+// WithContinuousAudit runs the auditor after each interaction changing the
+// content of the window; navigation, clicks, writing to form fields, and HTMX
+// swaps.
+//
+// The audit of a swap runs when HTMX has settled the content. Other changes are
+// audited before the scope is used again, i.e., before the next query, or when
+// the test completes; so the auditor sees the content after event handlers,
+// and navigation, have completed.
+func WithContinuousAudit(a Auditor) WindowScopeOption {
+	return func(c *windowContainerer) { c.audit = &continuousAudit{auditor: a} }
+}
+
+// continuousAudit keeps track of changes to the document of a window, running
+// the auditor when the content changed.
+type continuousAudit struct {
+	t       testing.TB
+	win     html.Window
+	auditor Auditor
+	doc     dom.Document
+	closer  dom.Closer
+	// step describes the interaction that changed the content since the last
+	// audit. Empty if the content is unchanged.
+	step string
+}
+
+func (c *continuousAudit) start(t testing.TB, win html.Window) {
+	c.t, c.win = t, win
+	c.checkpoint()
+	t.Cleanup(func() {
+		c.checkpoint()
+		c.stop()
+	})
+}
+
+// checkpoint audits the content if it changed since the last audit.
+func (c *continuousAudit) checkpoint() {
+	if c.win == nil {
+		return
+	}
+	if doc := c.win.Document(); doc != c.doc {
+		c.attach(doc)
+		c.step = fmt.Sprintf("navigation to %s", c.win.Location().Href())
+	}
+	if c.step == "" {
+		return
+	}
+	step := c.step
+	c.step = ""
+	c.auditor(c.t, Scope{c.t, windowContainerer{win: c.win}}, step)
+}
+
+// attach starts tracking changes to a new document, e.g., after navigation.
+func (c *continuousAudit) attach(doc dom.Document) {
+	c.stop()
+	c.doc = doc
+	if doc == nil {
+		return
+	}
+	c.closer = doc.Observe(continuousAuditObserver{c})
+	interaction := func(format string) event.EventHandler {
+		return event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			if target, ok := e.Target.(dom.Element); ok {
+				c.step = fmt.Sprintf(format, DescribeElement(target))
+			}
+		})
+	}
+	doc.AddEventListener("click", interaction("click on %s"))
+	doc.AddEventListener("input", interaction("write to %s"))
+	doc.AddEventListener("change", interaction("change of %s"))
+	doc.AddEventListener("htmx:afterSettle", event.NewEventHandlerFuncWithoutError(
+		func(e *event.Event) {
+			if target, ok := e.Target.(dom.Element); ok {
+				c.step = fmt.Sprintf("HTMX swap into %s", DescribeElement(target))
+			}
+			c.checkpoint()
+		},
+	))
+}
+
+func (c *continuousAudit) stop() {
+	if c.closer != nil {
+		c.closer.Close()
+		c.closer = nil
+	}
+}
+
+type continuousAuditObserver struct{ c *continuousAudit }
+
+// Process records changes to the content not caused by an event the audit
+// listens to, e.g., [TextboxRole.Write] setting the value.
+func (o continuousAuditObserver) Process(e dom.ChangeEvent) {
+	if o.c.step != "" {
+		return
+	}
+	target, ok := e.Target.(dom.Element)
+	if !ok {
+		target = e.Target.ParentElement()
+	}
+	switch {
+	case target == nil:
+		o.c.step = "change of content"
+	case e.Type == dom.ChangeEventAttributes && formField(target):
+		o.c.step = fmt.Sprintf("write to %s", DescribeElement(target))
+	case target.TagName() == "TEXTAREA":
+		o.c.step = fmt.Sprintf("write to %s", DescribeElement(target))
+	default:
+		o.c.step = fmt.Sprintf("change of %s", DescribeElement(target))
+	}
+}
+
+func formField(e dom.Element) bool { return formFieldPredicate{}.IsMatch(e) }
@@ -0,0 +1,53 @@
+package shaman_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContinuousAudit(t *testing.T) {
+	t.Parallel()
+	win, err := html.NewWindowReader(strings.NewReader(`<body><main>
+		<h1>Orders</h1>
+		<label>Name <input type="text" /></label>
+		<button>Add</button>
+		<div id="results"></div>
+	</main></body>`))
+	assert.NoError(t, err)
+	var steps []string
+	auditor := func(t testing.TB, s Scope, step string) { steps = append(steps, step) }
+	scope := WindowScope(t, win, WithContinuousAudit(auditor))
+	assert.Equal(t, []string{"navigation to about:blank"}, steps,
+		"The page is audited when the scope is created")
+
+	doc := win.Document()
+	button := scope.Get(ByRole(ariarole.Button))
+	button.AddEventListener("click", event.NewEventHandlerFuncWithoutError(
+		func(*event.Event) { doc.GetElementById("results").SetTextContent("Added") },
+	))
+	assert.Len(t, steps, 1, "Queries don't audit unchanged content")
+
+	button.Click()
+	scope.Textbox().Write("Order 1")
+	assert.Equal(t, []string{
+		"navigation to about:blank",
+		`click on button "Add"`,
+	}, steps, "Changes are audited before the next query")
+	scope.Get(ByRole(ariarole.Button))
+
+	results := doc.GetElementById("results")
+	results.DispatchEvent(&event.Event{Type: "htmx:afterSettle", Bubbles: true})
+	assert.Equal(t, []string{
+		"navigation to about:blank",
+		`click on button "Add"`,
+		`write to textbox "Name"`,
+		"HTMX swap into <div>",
+	}, steps, "HTMX swaps are audited when settled")
+}
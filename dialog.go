@@ -0,0 +1,166 @@
+package shaman
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+)
+
+type dialogPredicate struct{}
+
+func (dialogPredicate) IsMatch(e dom.Element) bool { return isOpenDialog(e) }
+
+func (dialogPredicate) String() string { return "By role: dialog, or alertdialog (open)" }
+
+// isOpenDialog returns whether e is a dialog displayed to the user. A <dialog>
+// element is displayed when it has the open attribute. A custom dialog is
+// displayed unless hidden.
+func isOpenDialog(e dom.Element) bool {
+	switch ariarole.GetElementRole(e) {
+	case ariarole.Dialog, ariarole.Alertdialog:
+	default:
+		return false
+	}
+	if e.TagName() == "DIALOG" {
+		return e.HasAttribute("open")
+	}
+	hidden, _ := e.GetAttribute("aria-hidden")
+	return !e.HasAttribute("hidden") && hidden != "true"
+}
+
+// isModalDialog returns whether e is an open modal dialog.
+//
+// Gost-DOM doesn't implement showModal(), so a <dialog> element is only
+// considered modal when it has the attribute aria-modal="true", as is the
+// case for custom dialogs.
+func isModalDialog(e dom.Element) bool {
+	modal, _ := e.GetAttribute("aria-modal")
+	return modal == "true" && isOpenDialog(e)
+}
+
+// openModalDialog returns the open modal dialog in the document of node n, or
+// nil if no modal dialog is open.
+func openModalDialog(n dom.Node) dom.Element {
+	doc, ok := n.(dom.Document)
+	if !ok {
+		doc = n.OwnerDocument()
+	}
+	if doc == nil {
+		return nil
+	}
+	for e := range NewScope(nil, doc).All() {
+		if isModalDialog(e) {
+			return e
+		}
+	}
+	return nil
+}
+
+// modalDialogCache holds the open modal dialog of a document, found again only
+// when the content of the document changed.
+type modalDialogCache struct {
+	modal  dom.Element
+	valid  bool
+	closer dom.Closer
+}
+
+func (c *modalDialogCache) Process(dom.ChangeEvent) { c.valid = false }
+
+var (
+	modalDialogsMu sync.Mutex
+	modalDialogs   = make(map[dom.Document]*modalDialogCache)
+)
+
+// documentModalDialog returns the open modal dialog of a document like
+// openModalDialog, but only scans the document when the content changed since
+// the last call. The cache of the document is removed when t completes.
+func documentModalDialog(t testing.TB, doc dom.Document) dom.Element {
+	modalDialogsMu.Lock()
+	defer modalDialogsMu.Unlock()
+	c, ok := modalDialogs[doc]
+	if !ok {
+		c = &modalDialogCache{}
+		c.closer = doc.Observe(c)
+		modalDialogs[doc] = c
+		t.Cleanup(func() {
+			modalDialogsMu.Lock()
+			defer modalDialogsMu.Unlock()
+			c.closer.Close()
+			if modalDialogs[doc] == c {
+				delete(modalDialogs, doc)
+			}
+		})
+	}
+	if !c.valid {
+		c.modal, c.valid = openModalDialog(doc), true
+	}
+	return c.modal
+}
+
+// Dialog finds the open dialog that matches the options, i.e., a <dialog open>
+// element, or an element with the role "dialog" or "alertdialog" that isn't
+// hidden.
+//
+// The returned [DialogRole] is also a [Scope] for finding elements inside the
+// dialog.
+func (s Scope) Dialog(opts ...ElementPredicate) DialogRole {
+	s.t.Helper()
+	opts = append(opts, dialogPredicate{})
+	e := s.Get(opts...)
+	return DialogRole{NewScope(s.t, e), e}
+}
+
+// DialogRole is a helper to interact with a [dialog], e.g., a confirmation
+// modal. The embedded Scope finds elements inside the dialog.
+//
+// While a modal dialog is open, the rest of the page is inert, i.e., the user
+// cannot interact with it. Finding an element outside the dialog, e.g., using
+// [Scope.Get], generates a test error, no matter the scope finding it.
+//
+// [dialog]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Reference/Roles/dialog_role
+type DialogRole struct {
+	Scope
+	html.HTMLElement
+}
+
+func (d DialogRole) unwrap() html.HTMLElement { return d.HTMLElement }
+
+// IsModal returns whether the dialog is modal, as indicated by aria-modal.
+func (d DialogRole) IsModal() bool { return isModalDialog(d.HTMLElement) }
+
+// Cancel simulates the user pressing escape. For a <dialog> element, a
+// cancelable "cancel" event is dispatched, and unless cancelled, the dialog is
+// closed. Custom dialogs must implement their own keyboard handling.
+func (d DialogRole) Cancel() {
+	if !pressKey(d.HTMLElement, "Escape") || d.TagName() != "DIALOG" {
+		return
+	}
+	if d.DispatchEvent(&event.Event{Type: "cancel", Cancelable: true}) {
+		d.closeNative()
+	}
+}
+
+// Close closes the dialog. A <dialog> element is closed as when calling
+// close(), removing the open attribute and dispatching a "close" event. For a
+// custom dialog, the button named "Close" in the dialog is clicked.
+func (d DialogRole) Close() {
+	d.t.Helper()
+	if d.TagName() == "DIALOG" {
+		d.closeNative()
+		return
+	}
+	d.Get(ByRole(ariarole.Button), ByName("Close")).Click()
+}
+
+func (d DialogRole) closeNative() {
+	if !d.HasAttribute("open") {
+		return
+	}
+	d.RemoveAttribute("open")
+	d.DispatchEvent(&event.Event{Type: "close"})
+}
@@ -0,0 +1,96 @@
+package shaman_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/internal/testtb"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialogNative(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<dialog aria-label="Confirm delete" open>
+			<p>Are you sure?</p>
+			<button>Delete</button>
+		</dialog>
+		<dialog aria-label="Closed dialog"></dialog>`)
+	var events []string
+	record := event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+		events = append(events, e.Type)
+	})
+
+	scope := NewScope(t, doc)
+	_, found := scope.Query(ByRole(ariarole.Dialog), ByName("Closed dialog"))
+	assert.True(t, found, "Closed dialog exists")
+
+	dialog := scope.Dialog(ByName("Confirm delete"))
+	dialog.AddEventListener("cancel", record)
+	dialog.AddEventListener("close", record)
+	assert.False(t, dialog.IsModal())
+	assert.Equal(t, "Delete", dialog.Get(ByRole(ariarole.Button)).TextContent())
+
+	dialog.Cancel()
+	assert.Equal(t, []string{"cancel", "close"}, events)
+	assert.False(t, dialog.HasAttribute("open"))
+}
+
+func TestDialogModal(t *testing.T) {
+	t.Parallel()
+	win, err := html.NewWindowReader(strings.NewReader(`<body>
+		<main><button>Delete</button></main>
+		<div id="modal" role="alertdialog" aria-modal="true" aria-label="Confirm deletion">
+			<button>Confirm</button>
+			<button>Close</button>
+		</div></body>`))
+	assert.NoError(t, err)
+	modal := win.Document().GetElementById("modal")
+	var closed bool
+	modal.AddEventListener("click",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			if e.Target.(dom.Element).TextContent() == "Close" {
+				closed = true
+			}
+		}))
+
+	tb := &testtb.RecordingTB{TB: t}
+	scope := WindowScope(tb, win)
+	dialog := scope.Dialog(ByName("Confirm deletion"))
+	assert.True(t, dialog.IsModal())
+	dialog.Get(ByName("Confirm"))
+	assert.Empty(t, tb.Errors, "Elements inside the modal dialog are not inert")
+
+	scope.Get(ByName("Delete"))
+	assert.Len(t, tb.Errors, 1, "Elements outside the modal dialog are inert")
+
+	dialog.Close()
+	assert.True(t, closed, "Close button clicked")
+}
+
+func TestDialogModalInertInAnyScope(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<main><form id="order"><button>Delete</button></form></main>
+		<div id="modal" role="dialog" aria-modal="true" aria-label="Confirm deletion">
+			<button>Confirm</button>
+		</div>`)
+	tb := &testtb.RecordingTB{TB: t}
+	scope := NewScope(tb, doc)
+	scope.Get(ByName("Delete"))
+	assert.Len(t, tb.Errors, 1, "Elements outside the modal dialog are inert")
+	form := NewScope(tb, doc.GetElementById("order"))
+	form.Get(ByName("Delete"))
+	assert.Len(t, tb.Errors, 2, "Elements are inert in a scope of an element")
+
+	doc.GetElementById("modal").SetAttribute("hidden", "")
+	scope.Get(ByName("Delete"))
+	form.Get(ByName("Delete"))
+	assert.Len(t, tb.Errors, 2, "Elements are not inert when the dialog is hidden")
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/node"
+
 	"github.com/gost-dom/browser/dom"
 )
 
@@ -21,17 +24,22 @@ import (
 // See also: https://developer.mozilla.org/en-US/docs/Web/Accessibility/Guides/Understanding_WCAG/Text_labels_and_names
 //
 // [accessibility name]: https://w3c.github.io/accname/#dfn-accessible-name
-func ElementName(e dom.Element) string {
+func ElementName(e dom.Element) string { return AccessibleName(node.FromElement(e)) }
+
+// AccessibleName returns the accessibility name of element e of any DOM
+// implementation, see package [node]. It returns empty string if e is nil.
+//
+// See also: [ElementName]
+func AccessibleName(e node.Element) string {
 	// TODO: This should be exposed as IDL attributes
 	if e == nil {
 		return ""
 	}
-	doc := e.OwnerDocument()
 	if l, ok := e.GetAttribute("aria-labelledby"); ok {
 		ids := strings.Split(l, " ")
 		labels := make([]string, 0, len(ids))
 		for _, id := range ids {
-			if labelElm := doc.GetElementById(id); labelElm != nil {
+			if labelElm := node.ElementByID(e, id); labelElm != nil {
 				labels = append(labels, labelElm.TextContent())
 			}
 		}
@@ -40,19 +48,145 @@ func ElementName(e dom.Element) string {
 	if l, ok := e.GetAttribute("aria-label"); ok {
 		return l
 	}
+	if ariarole.ElementRole(e) == ariarole.Treeitem {
+		return treeItemText(e)
+	}
 	switch e.TagName() {
-	case "INPUT":
+	case "INPUT", "SELECT", "TEXTAREA", "PROGRESS", "METER", "OUTPUT":
 		if id, ok := e.GetAttribute("id"); ok {
-			if label, _ := doc.QuerySelector(fmt.Sprintf("label[for='%s']", id)); label != nil {
+			if label := labelFor(e, id); label != nil {
 				return label.TextContent()
 			}
 		}
-	case "A", "BUTTON", "LI": // How many more? Can it be calculated from webref?
+		if label := nodeClosest(e, "LABEL"); label != nil {
+			return strings.TrimSpace(textExcluding(label, e))
+		}
+	case "FIELDSET":
+		if legend := nodeFirstChild(e, "LEGEND"); legend != nil {
+			return strings.TrimSpace(legend.TextContent())
+		}
+	case "TABLE":
+		if caption := nodeFirstChild(e, "CAPTION"); caption != nil {
+			return strings.TrimSpace(caption.TextContent())
+		}
+	case "IMG":
+		alt, _ := e.GetAttribute("alt")
+		return alt
+	case "A", "BUTTON", "LI", "OPTION": // How many more? Can it be calculated from webref?
+		return e.TextContent()
+	}
+	if nameFromContent[ariarole.ElementRole(e)] {
 		return e.TextContent()
 	}
 	return ""
 }
 
+// labelFor returns the first <label> in the tree of e with the for attribute
+// referencing the id. The tree is only searched when the root can't query the
+// label by a selector.
+func labelFor(e node.Element, id string) node.Element {
+	root := node.Root(e)
+	if q, ok := root.(node.SelectorQuerier); ok {
+		quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace
+		if l, err := q.QuerySelector(`label[for="` + quote(id) + `"]`); err == nil {
+			return l
+		}
+	}
+	for l := range node.Descendants(root) {
+		if v, ok := l.GetAttribute("for"); ok && v == id && l.TagName() == "LABEL" {
+			return l
+		}
+	}
+	return nil
+}
+
+// treeItemText returns the text of a tree item, excluding the text of the
+// nested group of child items.
+func treeItemText(e node.Element) string {
+	var b strings.Builder
+	for _, n := range e.ChildNodes() {
+		if c, ok := n.(node.Element); ok {
+			switch ariarole.ElementRole(c) {
+			case ariarole.Group, ariarole.Treeitem:
+				continue
+			}
+		}
+		b.WriteString(n.TextContent())
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// textExcluding returns the text content of n, excluding the content of
+// element x, e.g., the options of a <select> inside its <label>.
+func textExcluding(n node.Node, x node.Element) string {
+	var b strings.Builder
+	for _, c := range n.ChildNodes() {
+		switch c := c.(type) {
+		case node.Element:
+			if c != x {
+				b.WriteString(textExcluding(c, x))
+			}
+		case node.Text:
+			b.WriteString(c.TextContent())
+		}
+	}
+	return b.String()
+}
+
+// nodeClosest returns the nearest ancestor of e with the tag name, or nil if
+// none is found.
+func nodeClosest(e node.Element, tagName string) node.Element {
+	for p := node.ParentElement(e); p != nil; p = node.ParentElement(p) {
+		if p.TagName() == tagName {
+			return p
+		}
+	}
+	return nil
+}
+
+// nodeFirstChild returns the first child element of e with the tag name, or
+// nil if none is found.
+func nodeFirstChild(e node.Element, tagName string) node.Element {
+	for _, c := range node.Children(e) {
+		if c.TagName() == tagName {
+			return c
+		}
+	}
+	return nil
+}
+
+// closest is the Gost-DOM version of nodeClosest.
+func closest(e dom.Element, tagName string) dom.Element {
+	return domElement(nodeClosest(node.FromElement(e), tagName))
+}
+
+// firstChild is the Gost-DOM version of nodeFirstChild.
+func firstChild(e dom.Element, tagName string) dom.Element {
+	return domElement(nodeFirstChild(node.FromElement(e), tagName))
+}
+
+// nameFromContent contains the roles that [support name from content], i.e.,
+// elements with these roles, e.g., <div role="option">, use their text content
+// as the accessibility name if no explicit label is given.
+//
+// [support name from content]: https://www.w3.org/TR/wai-aria-1.2/#namefromcontent
+var nameFromContent = map[ariarole.Role]bool{
+	ariarole.Button:       true,
+	ariarole.Link:         true,
+	ariarole.Option:       true,
+	ariarole.Radio:        true,
+	ariarole.Cell:         true,
+	ariarole.Gridcell:     true,
+	ariarole.Columnheader: true,
+	ariarole.Rowheader:    true,
+	ariarole.Tab:          true,
+	ariarole.Heading:      true,
+
+	ariarole.Menuitem:         true,
+	ariarole.Menuitemcheckbox: true,
+	ariarole.Menuitemradio:    true,
+}
+
 // ElementDescription returns the [accessibility description] of an element. The
 // description provides additional context to complement the name. E.g.,
 // validation errors associated with an element would; or additional guidance
@@ -74,6 +208,41 @@ func ElementDescription(e dom.Element) string {
 	return s
 }
 
+// DescribeElement describes an element in messages by its role and name, e.g.,
+// `button "Save"`; or the tag name for elements without a role. A nil element
+// is described as "<nil>".
+func DescribeElement(e dom.Element) string {
+	if e == nil {
+		return "<nil>"
+	}
+	desc := string(ariarole.GetElementRole(e))
+	if desc == "" {
+		desc = "<" + strings.ToLower(e.TagName()) + ">"
+	}
+	if name := strings.TrimSpace(ElementName(e)); name != "" {
+		desc += fmt.Sprintf(" %q", name)
+	}
+	return desc
+}
+
+// DescribeElementHTML describes an element in messages like [DescribeElement],
+// followed by the HTML of the element, truncated to 200 characters, e.g.,
+// `button "Save": <button>Save</button>`. An element without a role is
+// described by the HTML only.
+func DescribeElementHTML(e dom.Element) string {
+	if e == nil {
+		return DescribeElement(e)
+	}
+	html := e.OuterHTML()
+	if len(html) > 200 {
+		html = html[:200] + "..."
+	}
+	if ariarole.GetElementRole(e) == ariarole.None {
+		return html
+	}
+	return DescribeElement(e) + ": " + html
+}
+
 // Older named versions. The new "Element" prefix seems better, as they
 // calculate some property of an element.
 
@@ -34,6 +34,7 @@ func TestElementName(t *testing.T) {
 			<input id="input-5" aria-labelledby="label-5a label-5b">
 			<p id="label-5a">Value 5a</p>
 			<p id="label-5b">Value 5b</p>
+			<label for='input-"6"\'>Value 6</label><input id='input-"6"\'>
 		`)
 		assert.Equal(t, "Value 1", ElementName(doc.GetElementById("input-1")))
 		assert.Equal(t, "Value 2", ElementName(doc.GetElementById("input-2")))
@@ -49,6 +50,10 @@ func TestElementName(t *testing.T) {
 			"Value 5a Value 5b", ElementName(doc.GetElementById("input-5")),
 			"aria-labelledby should accept multiple IDs",
 		)
+		assert.Equal(t,
+			"Value 6", ElementName(doc.GetElementById(`input-"6"\`)),
+			"Quotes in id",
+		)
 	})
 
 	t.Run("<button>", func(t *testing.T) {
@@ -61,3 +66,18 @@ func TestElementName(t *testing.T) {
 		assert.Equal(t, "Click me!", ElementName(doc.GetElementById("link")))
 	})
 }
+
+func TestElementNameFromAncestors(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<fieldset id="fieldset"><legend> Shipping </legend>
+			<label><input id="input" type="radio" /> Express</label>
+		</fieldset>
+		<label>Country <select id="select"><option>Denmark</option></select></label>`)
+	assert.Equal(t, "Shipping", ElementName(doc.GetElementById("fieldset")),
+		"<fieldset> is named by its <legend>")
+	assert.Equal(t, "Express", ElementName(doc.GetElementById("input")),
+		"<input> is named by a <label> ancestor")
+	assert.Equal(t, "Country", ElementName(doc.GetElementById("select")),
+		"The content of the labelled element is not part of the name")
+}
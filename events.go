@@ -0,0 +1,53 @@
+package shaman
+
+import (
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+)
+
+// KeyboardEventInit is the event data of keyboard events dispatched by shaman,
+// e.g., when simulating the user pressing escape to close a popup.
+//
+// Gost-DOM doesn't yet expose keyboard event data to JavaScript, so only Go
+// event listeners can read the key.
+type KeyboardEventInit struct {
+	Key string
+}
+
+// dispatchEvent dispatches a bubbling event of the specified type, e.g.,
+// "input", or "change".
+func dispatchEvent(e dom.Element, eventType string) bool {
+	return e.DispatchEvent(&event.Event{Type: eventType, Bubbles: true})
+}
+
+func newKeyboardEvent(eventType string, key string) *event.Event {
+	return &event.Event{
+		Type:       eventType,
+		Bubbles:    true,
+		Cancelable: true,
+		Data:       KeyboardEventInit{Key: key},
+	}
+}
+
+// pressKey simulates the user pressing a single key, e.g., "Escape", or
+// "ArrowDown", while element e has focus. Returns false if the keydown event
+// was cancelled.
+func pressKey(e dom.Element, key string) bool {
+	res := e.DispatchEvent(newKeyboardEvent("keydown", key))
+	e.DispatchEvent(newKeyboardEvent("keyup", key))
+	return res
+}
+
+// typeText simulates the user typing text into an input element, one key at a
+// time, appending to the current value.
+func typeText(input html.HTMLInputElement, text string) {
+	for _, r := range text {
+		key := string(r)
+		if input.DispatchEvent(newKeyboardEvent("keydown", key)) {
+			input.SetValue(input.Value() + key)
+			dispatchEvent(input, "input")
+		}
+		input.DispatchEvent(newKeyboardEvent("keyup", key))
+	}
+}
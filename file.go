@@ -0,0 +1,304 @@
+package shaman
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+)
+
+// File is a file a user selects in an <input type="file">.
+type File struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+// TestdataFile reads the file with the name from the "testdata" directory of
+// the package being tested. The content type is derived from the file
+// extension, defaulting to "application/octet-stream".
+func TestdataFile(t testing.TB, name string) File {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Error reading testdata file: %v", err)
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return File{Name: filepath.Base(name), ContentType: contentType, Content: content}
+}
+
+// filesEvent is the type of the event reading the files attached to a file
+// input. The browser doesn't implement the files of an <input type="file">, so
+// they are kept by a listener on the element, living as long as the element.
+const filesEvent = "shaman:files"
+
+// attachment is the data of a [filesEvent], set by the listener keeping the
+// files.
+type attachment struct {
+	files    []File
+	listener event.EventHandler
+}
+
+func attached(e dom.Element) attachment {
+	var a attachment
+	e.DispatchEvent(&event.Event{Type: filesEvent, Data: &a})
+	return a
+}
+
+func attachedFiles(e dom.Element) []File { return slices.Clone(attached(e).files) }
+
+type fileInputPredicate struct{}
+
+func (fileInputPredicate) IsMatch(e dom.Element) bool {
+	t, _ := e.GetAttribute("type")
+	return e.TagName() == "INPUT" && strings.EqualFold(t, "file")
+}
+
+func (fileInputPredicate) String() string { return "File input" }
+
+// FileInput finds the <input type="file"> element matching the options.
+func (s Scope) FileInput(opts ...ElementPredicate) FileInputRole {
+	s.t.Helper()
+	opts = append(opts, fileInputPredicate{})
+	return FileInputRole{s.Get(opts...), s.t}
+}
+
+// FileInputRole is a helper to select files in an <input type="file">.
+//
+// The files are included by [FormRole.Values], as the file names, and by
+// [FormRole.MultipartBody]. The content of the files is only submitted by a
+// form with enctype="multipart/form-data", see [FormRole.Submit], or by
+// requests of a page opened with [OpenHandler], e.g., HTMX requests.
+type FileInputRole struct {
+	html.HTMLElement
+	t testing.TB
+}
+
+func (fi FileInputRole) unwrap() html.HTMLElement { return fi.HTMLElement }
+
+// Attach simulates the user selecting files, replacing previously selected
+// files, and dispatching "input" and "change" events. A fatal error is
+// generated when attaching multiple files to an element without the multiple
+// attribute.
+func (fi FileInputRole) Attach(f ...File) {
+	fi.t.Helper()
+	if disabled(fi.HTMLElement) {
+		fi.t.Fatalf("Cannot attach files to disabled element: %s", fi.OuterHTML())
+		return
+	}
+	if len(f) > 1 && !fi.HasAttribute("multiple") {
+		fi.t.Fatalf("Cannot attach %d files to element without multiple attribute: %s",
+			len(f), fi.OuterHTML())
+		return
+	}
+	fi.setFiles(f)
+}
+
+// Clear removes the selected files, dispatching "input" and "change" events.
+func (fi FileInputRole) Clear() { fi.setFiles(nil) }
+
+// Files returns the selected files.
+func (fi FileInputRole) Files() []File { return attachedFiles(fi.HTMLElement) }
+
+func (fi FileInputRole) setFiles(f []File) {
+	if prev := attached(fi).listener; prev != nil {
+		fi.RemoveEventListener(filesEvent, prev)
+	}
+	if len(f) > 0 {
+		a := &attachment{files: slices.Clone(f)}
+		a.listener = event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			if data, ok := e.Data.(*attachment); ok {
+				*data = *a
+			}
+		})
+		fi.AddEventListener(filesEvent, a.listener)
+	}
+	if input, ok := fi.HTMLElement.(html.HTMLInputElement); ok {
+		// Browsers expose the first file name with a fake path as the value.
+		value := ""
+		if len(f) > 0 {
+			value = `C:\fakepath\` + f[0].Name
+		}
+		input.SetValue(value)
+	}
+	dispatchEvent(fi, "input")
+	dispatchEvent(fi, "change")
+}
+
+// MultipartBody returns the data the form would submit, encoded as
+// "multipart/form-data", including the content of attached files, as well as
+// the content type with the boundary.
+func (f FormRole) MultipartBody() (contentType string, body []byte) {
+	f.t.Helper()
+	contentType, body, err := multipartBody(f.HTMLElement, nil)
+	if err != nil {
+		f.t.Fatalf("Error writing multipart body: %v", err)
+	}
+	return contentType, body
+}
+
+// multipartBody encodes the form data set of the form, with the submitter, as
+// "multipart/form-data".
+func multipartBody(form dom.Element, submitter dom.Element) (string, []byte, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, entry := range formDataSet(form, submitter) {
+		var err error
+		if entry.file == nil {
+			err = w.WriteField(entry.name, entry.value)
+		} else {
+			err = writeFile(w, entry.name, *entry.file)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", nil, err
+	}
+	return w.FormDataContentType(), buf.Bytes(), nil
+}
+
+func writeFile(w *multipart.Writer, name string, file File) error {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(
+		`form-data; name="%s"; filename="%s"`, quote(name), quote(file.Name)))
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	h.Set("Content-Type", contentType)
+	part, err := w.CreatePart(h)
+	if err == nil {
+		_, err = part.Write(file.Content)
+	}
+	return err
+}
+
+// uploadHandler sends the content of attached files for requests made by the
+// page, before calling the handler. As the browser has no files, a form data
+// request, e.g., by HTMX, is url encoded, with the value of a file input, the
+// fake path of the file. If the request includes the value of a file input with
+// attached files, which sends its data encoded as "multipart/form-data", the
+// request is encoded as such, with the files.
+//
+// The handler runs on another goroutine than the browser, but the browser waits
+// for the response while the document is read.
+type uploadHandler struct {
+	h   http.Handler
+	doc func() dom.Document
+}
+
+func (h uploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if doc := h.doc(); doc != nil && r.Body != nil &&
+		mediaType == "application/x-www-form-urlencoded" {
+		if err := encodeUploads(doc, r); err != nil {
+			http.Error(w, fmt.Sprintf("Error encoding uploaded files: %v", err),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+	h.h.ServeHTTP(w, r)
+}
+
+// encodeUploads encodes the url encoded body of the request as
+// "multipart/form-data", if it includes the value of a multipart file input in
+// the document.
+func encodeUploads(doc dom.Document, r *http.Request) error {
+	inputs := make(map[string]dom.Element)
+	if all, err := doc.QuerySelectorAll("input[name]"); err == nil {
+		for _, n := range all.All() {
+			input, ok := n.(html.HTMLInputElement)
+			if ok && (fileInputPredicate{}).IsMatch(input) &&
+				multipartEncoded(input) && len(attachedFiles(input)) > 0 {
+				name, _ := input.GetAttribute("name")
+				inputs[name+"="+input.Value()] = input
+			}
+		}
+	}
+	if len(inputs) == 0 {
+		return nil
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	type field struct{ name, value string }
+	var fields []field
+	upload := false
+	for _, pair := range strings.Split(string(data), "&") {
+		if pair == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		name, err1 := url.QueryUnescape(name)
+		value, err2 := url.QueryUnescape(value)
+		if err := errors.Join(err1, err2); err != nil {
+			return err
+		}
+		fields = append(fields, field{name, value})
+		upload = upload || inputs[name+"="+value] != nil
+	}
+	if !upload {
+		return nil
+	}
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, f := range fields {
+		if input := inputs[f.name+"="+f.value]; input == nil {
+			err = w.WriteField(f.name, f.value)
+		} else {
+			for _, file := range attachedFiles(input) {
+				if err == nil {
+					err = writeFile(w, f.name, file)
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(&buf)
+	r.ContentLength = int64(buf.Len())
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return nil
+}
+
+// multipartEncoded returns whether the form data of the element is encoded as
+// "multipart/form-data"; by the enctype of the form, or an hx-encoding
+// attribute of HTMX.
+func multipartEncoded(e dom.Element) bool {
+	for ; e != nil; e = e.ParentElement() {
+		if encoding, ok := e.GetAttribute("hx-encoding"); ok {
+			return strings.EqualFold(encoding, "multipart/form-data")
+		}
+		if e.TagName() == "FORM" {
+			enctype, _ := e.GetAttribute("enctype")
+			return strings.EqualFold(enctype, "multipart/form-data")
+		}
+	}
+	return false
+}
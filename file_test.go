@@ -0,0 +1,159 @@
+package shaman_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser"
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileInput(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<form aria-label="Upload" method="post" enctype="multipart/form-data">
+			<label>Title <input type="text" name="title" value="Report" /></label>
+			<label>Attachment <input type="file" name="attachment" /></label>
+			<label>Images <input type="file" name="images" multiple /></label>
+		</form>`)
+	var changes int
+	doc.AddEventListener("change",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) { changes++ }))
+
+	form := NewScope(t, doc).Form(ByName("Upload"))
+	attachment := form.FileInput(ByName("Attachment"))
+	assert.Equal(t, url.Values{
+		"title":      {"Report"},
+		"attachment": {""},
+		"images":     {""},
+	}, form.Values())
+
+	hello := TestdataFile(t, "hello.txt")
+	assert.Equal(t, "hello.txt", hello.Name)
+	assert.Equal(t, "text/plain; charset=utf-8", hello.ContentType)
+
+	attachment.Attach(hello)
+	assert.Equal(t, 1, changes)
+	assert.Equal(t, []File{hello}, attachment.Files())
+	assert.Equal(t, `C:\fakepath\hello.txt`,
+		attachment.HTMLElement.(html.HTMLInputElement).Value())
+
+	form.FileInput(ByName("Images")).Attach(
+		File{Name: "a.png", ContentType: "image/png", Content: []byte("A")},
+		File{Name: "b.png", ContentType: "image/png", Content: []byte("B")},
+	)
+	assert.Equal(t, url.Values{
+		"title":      {"Report"},
+		"attachment": {"hello.txt"},
+		"images":     {"a.png", "b.png"},
+	}, form.Values())
+
+	contentType, body := form.MultipartBody()
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/form-data", mediaType)
+
+	type part struct{ name, fileName, contentType, content string }
+	var parts []part
+	r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for p, err := r.NextPart(); err == nil; p, err = r.NextPart() {
+		content, _ := io.ReadAll(p)
+		parts = append(parts,
+			part{p.FormName(), p.FileName(), p.Header.Get("Content-Type"), string(content)})
+	}
+	assert.Equal(t, []part{
+		{"title", "", "", "Report"},
+		{"attachment", "hello.txt", "text/plain; charset=utf-8", "Hello, world!\n"},
+		{"images", "a.png", "image/png", "A"},
+		{"images", "b.png", "image/png", "B"},
+	}, parts)
+
+	attachment.Clear()
+	assert.Empty(t, attachment.Files())
+	assert.Equal(t, 3, changes)
+
+	NewScope(t, doc).FileInput(ByName("Attachment")).Attach(hello)
+	assert.Equal(t, []File{hello}, attachment.Files(), "Files are kept by the element")
+	assert.Equal(t, 4, changes)
+}
+
+func TestFileInputSubmitMultipart(t *testing.T) {
+	t.Parallel()
+	type upload struct{ title, fileName, content string }
+	var uploaded upload
+	server := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/upload":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			f, h, err := r.FormFile("attachment")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			content, _ := io.ReadAll(f)
+			uploaded = upload{r.FormValue("title"), h.Filename, string(content)}
+			http.Redirect(w, r, "/done", http.StatusSeeOther)
+		case "/done":
+			fmt.Fprint(w, "<h1>Uploaded</h1>")
+		default:
+			fmt.Fprint(w, `
+				<form aria-label="Upload" method="post" action="/upload" enctype="multipart/form-data">
+					<label>Title <input type="text" name="title" value="Report" /></label>
+					<label>Attachment <input type="file" name="attachment" /></label>
+					<button>Upload</button>
+				</form>`)
+		}
+	})
+	win, err := browser.New(browser.WithHandler(server)).Open("http://example.com/")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	scope := WindowScope(t, win)
+	form := scope.Form(ByName("Upload"))
+	form.FileInput(ByName("Attachment")).Attach(TestdataFile(t, "hello.txt"))
+	form.Submit()
+
+	assert.Equal(t, upload{"Report", "hello.txt", "Hello, world!\n"}, uploaded)
+	assert.Equal(t, "Uploaded", scope.Get(ByH1).TextContent())
+	assert.Equal(t, "/done", win.Location().Pathname())
+}
+
+func TestFileInputSubmitMultipartStatus(t *testing.T) {
+	t.Parallel()
+	server := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			_, _, err := r.FormFile("attachment")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprintf(w, `<p role="alert">Invalid file: %v</p>`, err == nil)
+			return
+		}
+		fmt.Fprint(w, `
+			<form aria-label="Upload" method="post" action="/upload" enctype="multipart/form-data">
+				<label>Attachment <input type="file" name="attachment" /></label>
+				<button>Upload</button>
+			</form>`)
+	})
+	scope := OpenHandler(t, server, "/")
+	form := scope.Form(ByName("Upload"))
+	form.FileInput(ByName("Attachment")).Attach(TestdataFile(t, "hello.txt"))
+	nav := scope.WaitForNavigation(form.Submit)
+
+	assert.Equal(t, Navigation{"http://example.com/", http.StatusUnprocessableEntity}, nav)
+	assert.Equal(t, "Invalid file: true", scope.Get(ByRole(ariarole.Alert)).TextContent(),
+		"The response is loaded")
+}
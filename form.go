@@ -0,0 +1,413 @@
+package shaman
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+)
+
+// Form finds the <form> element, or element with the role "form", that matches
+// the options.
+//
+// The returned [FormRole] is also a [Scope] for finding elements inside the
+// form.
+func (s Scope) Form(opts ...ElementPredicate) FormRole {
+	s.t.Helper()
+	opts = append(opts, ByRole(ariarole.Form))
+	e := s.Get(opts...)
+	return FormRole{NewScope(s.t, e), e, s.Window()}
+}
+
+// FormRole is a helper to fill out and submit forms. The embedded Scope finds
+// elements inside the form.
+type FormRole struct {
+	Scope
+	html.HTMLElement
+	// win is the window of the scope finding the form, submitting a multipart
+	// form.
+	win html.Window
+}
+
+func (f FormRole) unwrap() html.HTMLElement { return f.HTMLElement }
+
+type formFieldPredicate struct{}
+
+func (formFieldPredicate) IsMatch(e dom.Element) bool {
+	switch ariarole.GetElementRole(e) {
+	case ariarole.Textbox,
+		ariarole.Searchbox,
+		ariarole.PasswordText,
+		ariarole.Checkbox,
+		ariarole.Combobox,
+		ariarole.Listbox,
+		ariarole.Spinbutton,
+		ariarole.Slider:
+		return true
+	}
+	return radioGroupPredicate{}.IsMatch(e) || fileInputPredicate{}.IsMatch(e)
+}
+
+func (formFieldPredicate) String() string { return "Form field" }
+
+// Fill fills out the form. The keys of fields are the accessibility names of
+// the form fields, and the values are the values to enter, dispatching to the
+// helper matching the role of the field:
+//   - Textboxes, search boxes, and password fields are written to, see
+//     [TextboxRole.Write].
+//   - Checkboxes are checked when the value is "true", "on", or "checked", and
+//     unchecked when it is "false", "off", or "".
+//   - Radio groups choose the radio button named by the value.
+//   - Comboboxes and listboxes select the option named by the value.
+//   - Sliders and spin buttons set the numeric value.
+//
+// File inputs cannot be filled out with text, and generate a fatal error;
+// attach files with [FileInputRole.Attach].
+//
+// The fields are filled out in no particular order.
+func (f FormRole) Fill(fields map[string]string) {
+	f.t.Helper()
+	for name, value := range fields {
+		f.fill(name, value)
+	}
+}
+
+func (f FormRole) fill(name, value string) {
+	f.t.Helper()
+	e := f.Get(ByName(name), formFieldPredicate{})
+	if (fileInputPredicate{}).IsMatch(e) {
+		f.t.Fatalf("Cannot fill out file input %q, use FileInputRole.Attach", name)
+		return
+	}
+	switch ariarole.GetElementRole(e) {
+	case ariarole.Textbox, ariarole.Searchbox, ariarole.PasswordText:
+		TextboxRole{e}.Write(value)
+	case ariarole.Checkbox:
+		switch value {
+		case "true", "on", "checked":
+			CheckboxRole{e}.Check()
+		case "false", "off", "":
+			CheckboxRole{e}.Uncheck()
+		default:
+			f.t.Fatalf("Invalid value for checkbox %q: %q", name, value)
+		}
+	case ariarole.Combobox:
+		ComboboxRole{e, f.t}.Select(value)
+	case ariarole.Listbox:
+		ListboxRole{e, f.t}.Select(value)
+	case ariarole.Spinbutton, ariarole.Slider:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			f.t.Fatalf("Invalid numeric value for %q: %q", name, value)
+			return
+		}
+		RangeRole{e, f.t}.SetValue(v)
+	default:
+		RadioGroupRole{e, f.t}.Choose(value)
+	}
+}
+
+// Values returns the data the form would submit, following the HTML
+// [form data set] algorithm, without a submitter.
+//
+// [form data set]: https://html.spec.whatwg.org/multipage/form-control-infrastructure.html#constructing-the-form-data-set
+func (f FormRole) Values() url.Values {
+	res := url.Values{}
+	for _, entry := range formDataSet(f.HTMLElement, nil) {
+		res.Add(entry.name, entry.value)
+	}
+	return res
+}
+
+// Submit submits the form by clicking the default button, i.e., the first
+// submit button of the form. If the form has no submit button, the form's
+// requestSubmit() is called.
+//
+// The data submitted is the data returned by [FormRole.Values], with the
+// submit button added.
+//
+// The browser only submits url encoded data, so a form with
+// enctype="multipart/form-data" is posted here, with the body returned by
+// [FormRole.MultipartBody], using the HTTP client of the window. Unlike the
+// browser, which only loads successful responses, the response is loaded into
+// the window no matter the status. This requires the form to be found in a
+// [WindowScope].
+func (f FormRole) Submit() {
+	f.t.Helper()
+	form, ok := f.HTMLElement.(html.HTMLFormElement)
+	if !ok {
+		f.t.Fatalf("Only <form> elements can be submitted: %s", f.OuterHTML())
+		return
+	}
+	submitter := defaultButton(form)
+	if enctype, _ := form.GetAttribute("enctype"); form.Method() == "post" &&
+		strings.EqualFold(enctype, "multipart/form-data") {
+		f.submitMultipart(form, submitter)
+		return
+	}
+	// The form data of the browser doesn't implement the full algorithm, so
+	// replace the entries with the data set constructed here.
+	handler := event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+		if data, ok := e.Data.(html.FormDataEventInit); ok {
+			data.FormData.Entries = nil
+			for _, entry := range formDataSet(form, submitter) {
+				data.FormData.Append(entry.name, html.FormDataValue(entry.value))
+			}
+		}
+	})
+	form.AddEventListener("formdata", handler)
+	defer form.RemoveEventListener("formdata", handler)
+	if submitter != nil {
+		submitter.Click()
+		return
+	}
+	if err := form.RequestSubmit(nil); err != nil {
+		f.t.Errorf("Error submitting form: %v", err)
+	}
+}
+
+func (f FormRole) submitMultipart(form html.HTMLFormElement, submitter html.HTMLElement) {
+	f.t.Helper()
+	if f.win == nil {
+		f.t.Fatalf("Submitting a multipart form requires a window scope: %s", f.OuterHTML())
+		return
+	}
+	// Let the submit event reach the listeners of the page, and prevent the
+	// browser's url encoded submission, unless the page already did.
+	var submit bool
+	handler := event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+		if !e.DefaultPrevented {
+			e.PreventDefault()
+			submit = true
+		}
+	})
+	f.win.AddEventListener("submit", handler)
+	defer f.win.RemoveEventListener("submit", handler)
+	if submitter != nil {
+		submitter.Click()
+	} else if err := form.RequestSubmit(nil); err != nil {
+		f.t.Errorf("Error submitting form: %v", err)
+		return
+	}
+	if !submit {
+		return
+	}
+
+	contentType, body, err := multipartBody(form, submitter)
+	if err != nil {
+		f.t.Fatalf("Error writing multipart body: %v", err)
+		return
+	}
+	req, err := http.NewRequest("POST", form.Action(), bytes.NewReader(body))
+	if err != nil {
+		f.t.Fatalf("Error submitting form: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", contentType)
+	// As for the browser's navigation, the location follows redirects.
+	client := f.win.HTTPClient()
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > 9 {
+			return html.ErrTooManyRedirects
+		}
+		return f.win.History().ReplaceState(html.EMPTY_STATE, req.URL.String())
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		f.t.Errorf("Error submitting form: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	// The response is loaded no matter the status, e.g., the form rendered
+	// again with validation errors.
+	page, err := io.ReadAll(resp.Body)
+	if err == nil {
+		err = f.win.LoadHTML(string(page))
+	}
+	if err != nil {
+		f.t.Errorf("Error loading response: %v", err)
+	}
+}
+
+// formElements returns the elements associated with the form in tree order;
+// the descendants of the form, and elements with a form attribute referencing
+// the form.
+func formElements(form dom.Element) []html.HTMLElement {
+	var root dom.ElementContainer = form
+	if id := form.ID(); id != "" {
+		root = form.OwnerDocument()
+	}
+	var res []html.HTMLElement
+	for e := range NewScope(nil, root).All() {
+		e, ok := e.(html.HTMLElement)
+		if !ok {
+			continue
+		}
+		switch e.TagName() {
+		case "INPUT", "SELECT", "TEXTAREA", "BUTTON":
+		default:
+			continue
+		}
+		if formOwner(e) == form {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// formOwner returns the form associated with the element; the form referenced
+// by the form attribute, or the nearest <form> ancestor.
+func formOwner(e dom.Element) dom.Element {
+	if id, ok := e.GetAttribute("form"); ok {
+		if form := e.OwnerDocument().GetElementById(id); form != nil && form.TagName() == "FORM" {
+			return form
+		}
+		return nil
+	}
+	return closest(e, "FORM")
+}
+
+// isSubmitButton returns whether e submits the form when clicked.
+func isSubmitButton(e dom.Element) bool {
+	switch e := e.(type) {
+	case html.HTMLInputElement:
+		return e.Type() == "submit" || e.Type() == "image"
+	case html.HTMLButtonElement:
+		return e.Type() == "submit"
+	}
+	return false
+}
+
+func defaultButton(form dom.Element) html.HTMLElement {
+	for _, e := range formElements(form) {
+		if isSubmitButton(e) {
+			return e
+		}
+	}
+	return nil
+}
+
+// disabled returns whether a form control is disabled, either by its own
+// disabled attribute, or by a disabled <fieldset> ancestor, unless the control
+// is inside the fieldset's first <legend>.
+func disabled(e dom.Element) bool {
+	if e.HasAttribute("disabled") {
+		return true
+	}
+	child := e
+	for p := e.ParentElement(); p != nil; p = p.ParentElement() {
+		if p.TagName() == "FIELDSET" && p.HasAttribute("disabled") {
+			legend := firstChild(p, "LEGEND")
+			if legend == nil || legend != child {
+				return true
+			}
+		}
+		child = p
+	}
+	return false
+}
+
+// formEntry is an entry of the form data set. For a file, value is the file
+// name.
+type formEntry struct {
+	name  string
+	value string
+	file  *File
+}
+
+// formDataSet constructs the entries the form submits with the submitter,
+// which may be nil.
+func formDataSet(form dom.Element, submitter dom.Element) []formEntry {
+	var res []formEntry
+	add := func(name, value string) {
+		res = append(res, formEntry{name: name, value: value})
+	}
+	for _, e := range formElements(form) {
+		name, _ := e.GetAttribute("name")
+		if disabled(e) || closest(e, "DATALIST") != nil {
+			continue
+		}
+		if isSubmitButton(e) || e.TagName() == "BUTTON" {
+			if e != submitter {
+				continue
+			}
+			if input, ok := e.(html.HTMLInputElement); ok && input.Type() == "image" {
+				prefix := ""
+				if name != "" {
+					prefix = name + "."
+				}
+				add(prefix+"x", "0")
+				add(prefix+"y", "0")
+				continue
+			}
+		}
+		if name == "" {
+			continue
+		}
+		switch e.TagName() {
+		case "SELECT":
+			for _, o := range selectedFormOptions(e) {
+				add(name, optionValue(o))
+			}
+		case "TEXTAREA":
+			add(name, e.TextContent())
+		case "BUTTON":
+			v, _ := e.GetAttribute("value")
+			add(name, v)
+		case "INPUT":
+			input, ok := e.(html.HTMLInputElement)
+			if !ok {
+				continue
+			}
+			switch input.Type() {
+			case "checkbox", "radio":
+				if !inputChecked(input) {
+					continue
+				}
+				v, ok := input.GetAttribute("value")
+				if !ok {
+					v = "on"
+				}
+				add(name, v)
+			case "reset", "button":
+			case "file":
+				files := attachedFiles(input)
+				if len(files) == 0 {
+					res = append(res, formEntry{name, "", &File{}})
+				}
+				for _, file := range files {
+					res = append(res, formEntry{name, file.Name, &file})
+				}
+			default:
+				add(name, input.Value())
+			}
+		}
+	}
+	return res
+}
+
+// selectedFormOptions returns the selected options of a <select> element
+// that are submitted with the form, i.e., excluding disabled options.
+func selectedFormOptions(sel dom.Element) []html.HTMLElement {
+	return slices.DeleteFunc(ElementSelectedOptions(sel), func(o html.HTMLElement) bool {
+		return ElementDisabled(o)
+	})
+}
+
+// optionValue returns the value of an <option>; the value attribute if
+// present, otherwise the text.
+func optionValue(o dom.Element) string {
+	if v, ok := o.GetAttribute("value"); ok {
+		return v
+	}
+	return cellText(o)
+}
@@ -0,0 +1,148 @@
+package shaman_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/internal/testtb"
+
+	"github.com/gost-dom/browser"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+const signupForm = `
+	<form aria-label="Sign up" method="post" action="/signup">
+		<label>Email <input type="text" name="email" /></label>
+		<label>Password <input type="password" name="password" /></label>
+		<label>Bio <textarea name="bio"></textarea></label>
+		<label><input type="checkbox" name="terms" /> Accept terms</label>
+		<label><input type="checkbox" name="news" value="yes" /> Newsletter</label>
+		<fieldset>
+			<legend>Plan</legend>
+			<label><input type="radio" name="plan" value="free" checked /> Free</label>
+			<label><input type="radio" name="plan" value="pro" /> Pro</label>
+		</fieldset>
+		<label for="country">Country</label>
+		<select id="country" name="country">
+			<option value="dk">Denmark</option>
+			<option value="se">Sweden</option>
+			<option>Norway</option>
+		</select>
+		<label>Seats <input type="number" name="seats" value="1" /></label>
+		<input type="text" name="disabled" value="x" disabled />
+		<input type="hidden" name="token" value="secret" />
+		<button type="button" name="other">Other</button>
+		<button name="action" value="signup">Sign up</button>
+	</form>`
+
+func TestFormValues(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, signupForm)
+	form := NewScope(t, doc).Form(ByName("Sign up"))
+
+	assert.Equal(t, url.Values{
+		"email":    {""},
+		"password": {""},
+		"bio":      {""},
+		"plan":     {"free"},
+		"country":  {"dk"},
+		"seats":    {"1"},
+		"token":    {"secret"},
+	}, form.Values())
+
+	form.Fill(map[string]string{
+		"Email":        "jd@example.com",
+		"Password":     "s3cret",
+		"Bio":          "Hello",
+		"Accept terms": "on",
+		"Newsletter":   "true",
+		"Plan":         "Pro",
+		"Country":      "Norway",
+		"Seats":        "3",
+	})
+
+	assert.Equal(t, url.Values{
+		"email":    {"jd@example.com"},
+		"password": {"s3cret"},
+		"bio":      {"Hello"},
+		"terms":    {"on"},
+		"news":     {"yes"},
+		"plan":     {"pro"},
+		"country":  {"Norway"},
+		"seats":    {"3"},
+		"token":    {"secret"},
+	}, form.Values())
+}
+
+func TestFormValuesCheckedness(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<form aria-label="Filters">
+			<label><input type="checkbox" name="archived" checked /> Archived</label>
+			<label><input type="checkbox" name="shared" checked /> Shared</label>
+		</form>`)
+	scope := NewScope(t, doc)
+	scope.Get(ByName("Archived")).Click()
+	scope.Get(ByName("Shared")).(html.HTMLInputElement).SetChecked(false)
+
+	assert.False(t, ElementChecked(scope.Get(ByName("Archived"))), "Unchecked by a click")
+	assert.False(t, ElementChecked(scope.Get(ByName("Shared"))), "Unchecked by the property")
+	assert.Empty(t, scope.Form(ByName("Filters")).Values())
+}
+
+func TestFormFillInputTypes(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<form aria-label="Profile">
+			<label>Search <input type="search" name="q" /></label>
+			<label>Avatar <input type="file" name="avatar" /></label>
+		</form>`)
+	tb := &testtb.RecordingTB{TB: t}
+	form := NewScope(tb, doc).Form(ByName("Profile"))
+	form.Fill(map[string]string{"Search": "shoes"})
+	assert.Equal(t, "shoes", form.Values().Get("q"))
+
+	form.Fill(map[string]string{"Avatar": "avatar.png"})
+	assert.Equal(t, []string{
+		`Cannot fill out file input "Avatar", use FileInputRole.Attach`,
+	}, tb.Errors)
+}
+
+func TestFormSubmit(t *testing.T) {
+	t.Parallel()
+	var submitted url.Values
+	server := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/signup":
+			r.ParseForm()
+			submitted = r.PostForm
+			fmt.Fprint(w, "<h1>Welcome</h1>")
+		default:
+			fmt.Fprint(w, signupForm)
+		}
+	})
+	win, err := browser.New(browser.WithHandler(server)).Open("http://example.com/")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	form := WindowScope(t, win).Form(ByName("Sign up"))
+	form.Fill(map[string]string{"Email": "jd@example.com", "Plan": "Pro"})
+	form.Submit()
+
+	assert.Equal(t, "Welcome", WindowScope(t, win).Get(ByH1).TextContent())
+	assert.Equal(t, url.Values{
+		"email":    {"jd@example.com"},
+		"password": {""},
+		"bio":      {""},
+		"plan":     {"pro"},
+		"country":  {"dk"},
+		"seats":    {"1"},
+		"token":    {"secret"},
+		"action":   {"signup"},
+	}, submitted, "Submitted data includes the default button")
+}
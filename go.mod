@@ -4,11 +4,14 @@ go 1.24.2
 
 require (
 	github.com/gost-dom/browser v0.9.0
+	github.com/onsi/gomega v1.37.0
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/net v0.40.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/go-cmp v0.7.0 // indirect
 	github.com/gost-dom/css v0.1.0 // indirect
 	github.com/gost-dom/v8go v0.0.0-20250611154133-52960d7a56be // indirect
 	github.com/kr/pretty v0.3.1 // indirect
@@ -19,6 +22,7 @@ require (
 	github.com/tommie/v8go/deps/darwin_arm64 v0.0.0-20250521203357-c9a10f00f747 // indirect
 	github.com/tommie/v8go/deps/linux_amd64 v0.0.0-20250521203357-c9a10f00f747 // indirect
 	github.com/tommie/v8go/deps/linux_arm64 v0.0.0-20250521203357-c9a10f00f747 // indirect
-	golang.org/x/net v0.40.0 // indirect
+	go.uber.org/automaxprocs v1.6.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,229 @@
+package gomega
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+// pollTimeout is the maximum time a scope matcher waits for pending events of
+// the window, before reporting a mismatch. Eventually calls the matcher again
+// until it times out.
+const pollTimeout = 10 * time.Millisecond
+
+// HaveRole succeeds if the actual element has the [ARIA role].
+//
+// [ARIA role]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Roles
+func HaveRole(role ariarole.Role) types.GomegaMatcher {
+	return elementMatcher{
+		desc: fmt.Sprintf("to have role %s", role),
+		match: func(e dom.Element) bool {
+			return ariarole.GetElementRole(e) == role
+		},
+	}
+}
+
+// HaveName succeeds if the [accessibility name] of the actual element matches
+// the expected value; a string, or a matcher, e.g., ContainSubstring("Save").
+//
+// [accessibility name]: https://developer.mozilla.org/en-US/docs/Glossary/Accessible_name
+func HaveName(expected any) types.GomegaMatcher {
+	return propertyMatcher{"accessibility name", shaman.ElementName, toMatcher(expected)}
+}
+
+// HaveDescription succeeds if the [accessibility description] of the actual
+// element matches the expected value; a string, or a matcher.
+//
+// [accessibility description]: https://w3c.github.io/accname/#dfn-accessible-description
+func HaveDescription(expected any) types.GomegaMatcher {
+	return propertyMatcher{"accessibility description", shaman.ElementDescription, toMatcher(expected)}
+}
+
+// BeChecked succeeds if the actual element is checked.
+//
+// See also: [shaman.ElementChecked]
+func BeChecked() types.GomegaMatcher {
+	return elementMatcher{desc: "to be checked", match: shaman.ElementChecked}
+}
+
+// BeDisabled succeeds if the actual element is disabled.
+//
+// See also: [shaman.ElementDisabled]
+func BeDisabled() types.GomegaMatcher {
+	return elementMatcher{desc: "to be disabled", match: shaman.ElementDisabled}
+}
+
+// BeVisible succeeds if the actual element is visible.
+//
+// See also: [shaman.ElementVisible]
+func BeVisible() types.GomegaMatcher {
+	return elementMatcher{desc: "to be visible", match: shaman.ElementVisible}
+}
+
+// HaveFocus succeeds if the actual element is the active element of the
+// document.
+func HaveFocus() types.GomegaMatcher {
+	return elementMatcher{desc: "to have focus", match: shaman.ElementFocused}
+}
+
+// ContainElementMatching succeeds if the actual [shaman.Scope], or
+// [dom.ElementContainer], contains an element matching all the predicates.
+func ContainElementMatching(preds ...shaman.ElementPredicate) types.GomegaMatcher {
+	return containMatcher{preds}
+}
+
+// ContainElement is the same as [ContainElementMatching], reading naturally
+// with Eventually.
+//
+//	g.Eventually(scope).Should(ContainElement(ByRole(ariarole.Alert)))
+//
+// The name is the same as gomega's ContainElement, which matches an item of a
+// collection, so this package and gomega cannot both be dot imported. Import
+// one of them with a package name, e.g.:
+//
+//	import (
+//		. "github.com/gost-dom/shaman/gomega"
+//		g "github.com/onsi/gomega"
+//	)
+func ContainElement(preds ...shaman.ElementPredicate) types.GomegaMatcher {
+	return ContainElementMatching(preds...)
+}
+
+// elementMatcher matches an element by a property without a value, e.g.,
+// checked.
+type elementMatcher struct {
+	desc  string
+	match func(dom.Element) bool
+}
+
+func (m elementMatcher) Match(actual any) (bool, error) {
+	e, err := toElement(actual)
+	if err != nil {
+		return false, err
+	}
+	return m.match(e), nil
+}
+
+func (m elementMatcher) FailureMessage(actual any) string {
+	return fmt.Sprintf("Expected\n\t%s\n%s", describe(actual), m.desc)
+}
+
+func (m elementMatcher) NegatedFailureMessage(actual any) string {
+	return fmt.Sprintf("Expected\n\t%s\nnot %s", describe(actual), m.desc)
+}
+
+// propertyMatcher matches the value of a property of an element, e.g., the
+// accessibility name.
+type propertyMatcher struct {
+	name     string
+	get      func(dom.Element) string
+	expected types.GomegaMatcher
+}
+
+func (m propertyMatcher) Match(actual any) (bool, error) {
+	e, err := toElement(actual)
+	if err != nil {
+		return false, err
+	}
+	return m.expected.Match(m.get(e))
+}
+
+func (m propertyMatcher) FailureMessage(actual any) string {
+	e, _ := toElement(actual)
+	return fmt.Sprintf("Expected %s of %s\n%s",
+		m.name, describe(actual), m.expected.FailureMessage(m.get(e)))
+}
+
+func (m propertyMatcher) NegatedFailureMessage(actual any) string {
+	e, _ := toElement(actual)
+	return fmt.Sprintf("Expected %s of %s\n%s",
+		m.name, describe(actual), m.expected.NegatedFailureMessage(m.get(e)))
+}
+
+// containMatcher matches a scope containing an element matching the
+// predicates.
+type containMatcher struct {
+	preds []shaman.ElementPredicate
+}
+
+func (m containMatcher) Match(actual any) (bool, error) {
+	scope, err := toScope(actual)
+	if err != nil {
+		return false, err
+	}
+	found := func() bool {
+		for range scope.FindAll(m.preds...) {
+			return true
+		}
+		return false
+	}
+	if found() {
+		return true, nil
+	}
+	if win := scope.Window(); win != nil && win.ScriptContext() != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), pollTimeout)
+		defer cancel()
+		win.Clock().ProcessEventsWhile(ctx, func() bool { return !found() })
+	}
+	return found(), nil
+}
+
+func (m containMatcher) FailureMessage(actual any) string {
+	return fmt.Sprintf("Expected scope to contain element matching: %s", m.describePredicates())
+}
+
+func (m containMatcher) NegatedFailureMessage(actual any) string {
+	scope, _ := toScope(actual)
+	var matches []string
+	for e := range scope.FindAll(m.preds...) {
+		matches = append(matches, "\t"+shaman.DescribeElementHTML(e))
+	}
+	return fmt.Sprintf("Expected scope not to contain element matching: %s\nMatches:\n%s",
+		m.describePredicates(), strings.Join(matches, "\n"))
+}
+
+func (m containMatcher) describePredicates() string {
+	if len(m.preds) == 0 {
+		return "any element"
+	}
+	return shaman.DescribePredicates(m.preds...)
+}
+
+func toMatcher(expected any) types.GomegaMatcher {
+	if m, ok := expected.(types.GomegaMatcher); ok {
+		return m
+	}
+	return &matchers.EqualMatcher{Expected: expected}
+}
+
+func toElement(actual any) (dom.Element, error) {
+	if e, ok := actual.(dom.Element); ok && e != nil {
+		return e, nil
+	}
+	return nil, fmt.Errorf("Expected an element. Got: %T", actual)
+}
+
+func toScope(actual any) (shaman.Scope, error) {
+	switch a := actual.(type) {
+	case shaman.Scope:
+		return a, nil
+	case dom.ElementContainer:
+		return shaman.NewScope(nil, a), nil
+	}
+	return shaman.Scope{}, fmt.Errorf("Expected a shaman.Scope or dom.ElementContainer. Got: %T", actual)
+}
+
+func describe(actual any) string {
+	if e, ok := actual.(dom.Element); ok && e != nil {
+		return shaman.DescribeElementHTML(e)
+	}
+	return fmt.Sprintf("%v", actual)
+}
@@ -0,0 +1,129 @@
+package gomega_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	. "github.com/gost-dom/shaman/gomega"
+
+	"github.com/gost-dom/browser"
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+	"github.com/onsi/gomega"
+)
+
+func loadHTML(t testing.TB, h string) dom.Document {
+	win, err := html.NewWindowReader(strings.NewReader(h))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return win.Document()
+}
+
+const signupForm = `<body><main>
+	<form aria-label="Sign up">
+		<label>Email <input type="text" aria-describedby="email-error" /></label>
+		<p id="email-error">Invalid email</p>
+		<label><input type="checkbox" checked /> Accept terms</label>
+		<label><input type="checkbox" /> Newsletter</label>
+		<div role="switch" aria-checked="true">Dark mode</div>
+		<fieldset disabled>
+			<legend>Plan</legend>
+			<button type="button">Upgrade</button>
+		</fieldset>
+		<button aria-disabled="true">Sign up</button>
+		<p hidden>Secret</p>
+		<p style="display: none">Also secret</p>
+	</form>
+</main></body>`
+
+func TestElementMatchers(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+	scope := shaman.NewScope(t, loadHTML(t, signupForm))
+
+	email := scope.Textbox(shaman.ByName("Email"))
+	g.Expect(email).To(HaveRole(ariarole.Textbox))
+	g.Expect(email).To(HaveName("Email"))
+	g.Expect(email).To(HaveDescription(gomega.ContainSubstring("Invalid")))
+	g.Expect(email).ToNot(BeDisabled())
+	g.Expect(email).ToNot(HaveFocus())
+	email.Focus()
+	g.Expect(email).To(HaveFocus())
+
+	g.Expect(scope.Checkbox(shaman.ByName("Accept terms"))).To(BeChecked())
+	g.Expect(scope.Checkbox(shaman.ByName("Newsletter"))).ToNot(BeChecked())
+	g.Expect(scope.Get(shaman.ByRole(ariarole.Switch))).To(BeChecked())
+
+	g.Expect(scope.Get(shaman.ByName("Upgrade"))).To(BeDisabled())
+	g.Expect(scope.Get(shaman.ByName("Sign up"), shaman.ByRole(ariarole.Button))).To(BeDisabled())
+
+	form := scope.Get(shaman.ByRole(ariarole.Form))
+	g.Expect(email).To(BeVisible())
+	for p := range scope.FindAll(shaman.ElementPredicateFunc(func(e dom.Element) bool {
+		return e.TagName() == "P" && strings.Contains(e.TextContent(), "ecret")
+	})) {
+		g.Expect(p).ToNot(BeVisible())
+	}
+
+	g.Expect(scope).To(ContainElementMatching(shaman.ByRole(ariarole.Button), shaman.ByName("Sign up")))
+	g.Expect(form).ToNot(ContainElementMatching(shaman.ByRole(ariarole.Alert)))
+}
+
+func TestMatcherFailureMessages(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+	scope := shaman.NewScope(t, loadHTML(t, `<body><button>Save</button></body>`))
+	button := scope.Get(shaman.ByRole(ariarole.Button))
+
+	m := BeDisabled()
+	g.Expect(m.Match(button)).To(gomega.BeFalse())
+	g.Expect(m.FailureMessage(button)).To(gomega.Equal(
+		"Expected\n\tbutton \"Save\": <button>Save</button>\nto be disabled"))
+
+	m = HaveName("Cancel")
+	g.Expect(m.Match(button)).To(gomega.BeFalse())
+	g.Expect(m.FailureMessage(button)).To(gomega.HavePrefix(
+		"Expected accessibility name of button \"Save\": <button>Save</button>\n"))
+
+	m = ContainElement(shaman.ByRole(ariarole.Alert))
+	g.Expect(m.Match(scope)).To(gomega.BeFalse())
+	g.Expect(m.FailureMessage(scope)).To(gomega.Equal(
+		"Expected scope to contain element matching: By role: alert"))
+
+	_, err := m.Match("not a scope")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestEventuallyContainElement(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+	b := browser.New(browser.WithHandler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/message":
+				fmt.Fprint(w, `"Saved"`)
+			default:
+				fmt.Fprint(w, `<body><main></main><script>
+					fetch("/message").then((r) => r.json()).then((text) => {
+						const alert = document.createElement("div");
+						alert.setAttribute("role", "alert");
+						alert.textContent = text;
+						document.querySelector("main").appendChild(alert);
+					});
+				</script></body>`)
+			}
+		},
+	)))
+	t.Cleanup(b.Close)
+	win, err := b.Open("http://example.com/")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	scope := shaman.WindowScope(t, win)
+
+	g.Eventually(scope).Should(ContainElement(shaman.ByRole(ariarole.Alert)))
+	g.Expect(scope.Get(shaman.ByRole(ariarole.Alert)).TextContent()).To(gomega.Equal("Saved"))
+}
@@ -0,0 +1,19 @@
+// Package gomega provides [Gomega] matchers for accessibility properties of
+// elements, and for finding elements in a shaman scope.
+//
+//	g := gomega.NewWithT(t)
+//	button := scope.Get(ByRole(ariarole.Button), ByName("Save"))
+//	g.Expect(button).To(BeDisabled())
+//	g.Expect(scope.Textbox(ByName("Email"))).To(HaveDescription("Invalid email"))
+//
+// Matchers of a scope are async-friendly. When the matcher doesn't match a
+// window scope, pending events of the window are processed before trying
+// again; so Eventually waits for, e.g., the response of an HTMX request.
+//
+//	g.Eventually(scope).Should(ContainElement(ByRole(ariarole.Alert)))
+//
+// [ContainElement] has the same name as a gomega matcher, so this package and
+// gomega cannot both be dot imported.
+//
+// [Gomega]: https://onsi.github.io/gomega/
+package gomega
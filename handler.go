@@ -0,0 +1,121 @@
+package shaman
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gost-dom/browser"
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+// DefaultBaseURL is the base URL of pages opened by [OpenHandler], unless
+// configured with [WithBaseURL].
+const DefaultBaseURL = "http://example.com/"
+
+// HandlerOption configures [OpenHandler].
+type HandlerOption func(*handlerConfig)
+
+type handlerConfig struct {
+	baseURL      string
+	header       http.Header
+	cookies      []*http.Cookie
+	scopeOptions []WindowScopeOption
+}
+
+// WithBaseURL sets the URL the path is resolved against, e.g.,
+// "https://example.com/app/". This is the origin of the page, and the URL of
+// cookies added with [WithCookies].
+func WithBaseURL(u string) HandlerOption {
+	return func(c *handlerConfig) { c.baseURL = u }
+}
+
+// WithHeader adds a header to every request to the handler, including
+// requests made by JavaScript, e.g., HTMX requests.
+func WithHeader(key, value string) HandlerOption {
+	return func(c *handlerConfig) { c.header.Add(key, value) }
+}
+
+// WithCookies adds cookies to the browser's cookie jar before opening the page,
+// e.g., a session cookie of a signed in user. Cookies set by the handler are
+// stored in the same jar.
+func WithCookies(cookies ...*http.Cookie) HandlerOption {
+	return func(c *handlerConfig) { c.cookies = append(c.cookies, cookies...) }
+}
+
+// WithScopeOptions configures the [WindowScope] returned by [OpenHandler],
+// e.g., [WithContinuousAudit].
+func WithScopeOptions(opts ...WindowScopeOption) HandlerOption {
+	return func(c *handlerConfig) { c.scopeOptions = append(c.scopeOptions, opts...) }
+}
+
+// OpenHandler creates a browser sending requests directly to the handler,
+// opens the path, and returns a [WindowScope] for the window. The browser is
+// closed when the test completes. A fatal error is generated if the page
+// cannot be opened, e.g., the handler responds with a non-200 status code.
+//
+// Files attached with [FileInputRole.Attach] are sent by requests of the page
+// with a "multipart/form-data" encoding, e.g., an HTMX request with
+// hx-encoding="multipart/form-data".
+//
+//	scope := shaman.OpenHandler(t, server, "/orders")
+//	scope.Get(shaman.ByH1)
+func OpenHandler(t testing.TB, h http.Handler, path string, opts ...HandlerOption) Scope {
+	t.Helper()
+	c := handlerConfig{baseURL: DefaultBaseURL, header: make(http.Header)}
+	for _, o := range opts {
+		o(&c)
+	}
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		t.Fatalf("OpenHandler: invalid base URL: %v", err)
+		return Scope{}
+	}
+	location, err := base.Parse(path)
+	if err != nil {
+		t.Fatalf("OpenHandler: invalid path: %v", err)
+		return Scope{}
+	}
+	if len(c.header) > 0 {
+		h = headerHandler{h, c.header}
+	}
+	var win html.Window
+	h = uploadHandler{h, func() dom.Document {
+		if win == nil {
+			return nil
+		}
+		return win.Document()
+	}}
+	responses := &responseLog{}
+	h = statusHandler{h, responses}
+	b := browser.New(browser.WithHandler(h))
+	t.Cleanup(b.Close)
+	if len(c.cookies) > 0 {
+		b.Client.Jar.SetCookies(base, c.cookies)
+	}
+	win, err = b.Open(location.String())
+	if err != nil {
+		t.Fatalf("OpenHandler: error opening %s: %v", location, err)
+		return Scope{}
+	}
+	scopeOptions := append([]WindowScopeOption{withResponses(responses)}, c.scopeOptions...)
+	return WindowScope(t, win, scopeOptions...)
+}
+
+// headerHandler adds headers to requests before calling the handler.
+type headerHandler struct {
+	h      http.Handler
+	header http.Header
+}
+
+func (h headerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for k, v := range h.header {
+		r.Header[k] = append(r.Header[k], v...)
+	}
+	h.h.ServeHTTP(w, r)
+}
+
+func withResponses(l *responseLog) WindowScopeOption {
+	return func(c *windowContainerer) { c.responses = l }
+}
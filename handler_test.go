@@ -0,0 +1,37 @@
+package shaman_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenHandler(t *testing.T) {
+	t.Parallel()
+	var requests []*http.Request
+	server := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		user := "anonymous"
+		if c, err := r.Cookie("session"); err == nil {
+			user = c.Value
+		}
+		fmt.Fprintf(w, "<main><h1>Orders of %s</h1></main>", user)
+	})
+
+	scope := OpenHandler(t, server, "/orders")
+	assert.Equal(t, "Orders of anonymous", scope.Get(ByH1).TextContent())
+	assert.Equal(t, "http://example.com/orders", requests[0].URL.String())
+
+	scope = OpenHandler(t, server, "orders?page=2",
+		WithBaseURL("https://shop.example.com/app/"),
+		WithCookies(&http.Cookie{Name: "session", Value: "jane"}),
+		WithHeader("Accept-Language", "da"),
+	)
+	assert.Equal(t, "Orders of jane", scope.Get(ByH1).TextContent())
+	assert.Equal(t, "https://shop.example.com/app/orders?page=2", requests[1].URL.String())
+	assert.Equal(t, "da", requests[1].Header.Get("Accept-Language"))
+}
@@ -0,0 +1,283 @@
+package htmx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+// waitTimeout is the maximum time to wait for HTMX; both the time waiting for
+// responses, and the time the clock is advanced for delayed swaps and settles.
+const waitTimeout = time.Second
+
+// advanceStep is the time the clock is advanced, when waiting for a delayed
+// swap or settle, e.g., htmx.config.defaultSettleDelay.
+const advanceStep = 10 * time.Millisecond
+
+// Request is a request made by HTMX.
+type Request struct {
+	// Verb is the HTTP method in upper case, e.g., "POST".
+	Verb string
+	// URL is the absolute URL of the request, including the query string for
+	// a GET request.
+	URL string
+	// Target is the element the response is swapped into.
+	Target dom.Element
+	// Settled is true when the response has been swapped into the target, and
+	// the content has settled.
+	Settled bool
+
+	id int
+}
+
+func (r Request) String() string {
+	return fmt.Sprintf("%s %s into %s", r.Verb, r.URL, describe(r.Target))
+}
+
+// Monitor listens to the HTMX events of a window; recording requests, and
+// keeping track of requests in flight.
+//
+// The monitor is installed in the script context of the window. After a
+// navigation, it is installed in the new script context the next time the
+// monitor is used, so requests made by the new page before that aren't
+// recorded.
+type Monitor struct {
+	t        testing.TB
+	win      html.Window
+	ctx      html.ScriptContext
+	requests []Request
+	inFlight int
+	swapping int
+	waited   int
+}
+
+// New creates a monitor for the HTMX requests of the window. A fatal error is
+// generated if the window has no script engine.
+func New(t testing.TB, win html.Window) *Monitor {
+	t.Helper()
+	m := &Monitor{t: t, win: win}
+	m.sync()
+	return m
+}
+
+// Requests returns the requests made by HTMX, in the order they were made.
+func (m *Monitor) Requests() []Request {
+	m.t.Helper()
+	m.sync()
+	return slices.Clone(m.requests)
+}
+
+// InFlight returns the number of requests that have not yet completed.
+func (m *Monitor) InFlight() int {
+	m.t.Helper()
+	m.sync()
+	return m.inFlight
+}
+
+// WaitForSwap waits for a response to be swapped into the target, and settled;
+// and returns the request. Only requests made after the request returned by
+// the previous call are considered. Pending events are processed while
+// waiting. A fatal error is generated if no response is swapped into the
+// target.
+//
+// The target is the element identified by hx-target, or the element issuing
+// the request. For an outerHTML swap, this is the element that was replaced.
+func (m *Monitor) WaitForSwap(target dom.Element) Request {
+	m.t.Helper()
+	find := func() int {
+		m.sync()
+		for i := m.waited; i < len(m.requests); i++ {
+			if r := m.requests[i]; r.Settled && r.Target == target {
+				return i
+			}
+		}
+		return -1
+	}
+	i := -1
+	m.waitUntil(func() bool { i = find(); return i != -1 })
+	if i == -1 {
+		m.t.Fatalf("htmx: no swap into %s\nRequests: %v", describe(target), m.requests[m.waited:])
+		return Request{}
+	}
+	m.waited = i + 1
+	return m.requests[i]
+}
+
+// WaitForIdle waits until no requests are in flight, and all swapped content
+// has settled. Pending events are processed while waiting. A fatal error is
+// generated if HTMX doesn't become idle.
+func (m *Monitor) WaitForIdle() {
+	m.t.Helper()
+	idle := func() bool {
+		m.sync()
+		return m.inFlight == 0 && m.swapping <= 0
+	}
+	if !m.waitUntil(idle) {
+		m.t.Fatalf("htmx: not idle; %d requests in flight, %d swaps not settled",
+			m.inFlight, m.swapping)
+	}
+}
+
+// waitUntil processes events until the condition is true. While requests are
+// in flight, it waits for the responses; otherwise, the clock is advanced, as
+// HTMX delays swaps, and settles, with timers.
+func (m *Monitor) waitUntil(cond func() bool) bool {
+	deadline := time.Now().Add(waitTimeout)
+	var advanced time.Duration
+	for !cond() {
+		if m.inFlight > 0 {
+			if time.Now().After(deadline) {
+				return false
+			}
+			ctx, cancel := context.WithDeadline(context.Background(), deadline)
+			m.win.Clock().ProcessEventsWhile(ctx, func() bool { return !cond() && m.inFlight > 0 })
+			cancel()
+			continue
+		}
+		if advanced >= waitTimeout {
+			return false
+		}
+		m.win.Clock().Advance(advanceStep)
+		advanced += advanceStep
+	}
+	return true
+}
+
+// sync installs the monitor in the script context of the window, and reads
+// the events recorded since the last call.
+func (m *Monitor) sync() {
+	m.t.Helper()
+	ctx := m.win.ScriptContext()
+	if ctx == nil {
+		m.t.Fatalf("htmx: the window has no script engine")
+		return
+	}
+	if ctx != m.ctx {
+		m.ctx = ctx
+		m.inFlight, m.swapping = 0, 0
+		if err := ctx.Run(monitorScript); err != nil {
+			m.t.Fatalf("htmx: error installing monitor: %v", err)
+		}
+		return
+	}
+	res, err := ctx.Eval("__shamanHTMX.drain()")
+	if err != nil {
+		m.t.Fatalf("htmx: error reading events: %v", err)
+		return
+	}
+	state, _ := res.([]any)
+	if len(state) != 3 {
+		m.t.Fatalf("htmx: unexpected events: %v", res)
+		return
+	}
+	events, _ := state[0].([]any)
+	for _, e := range events {
+		m.process(e)
+	}
+	m.inFlight = toInt(state[1])
+	m.swapping = toInt(state[2])
+}
+
+func (m *Monitor) process(e any) {
+	values, _ := e.([]any)
+	if len(values) < 2 {
+		return
+	}
+	id := toInt(values[1])
+	switch values[0] {
+	case "request":
+		if len(values) != 5 {
+			return
+		}
+		verb, _ := values[2].(string)
+		path, _ := values[3].(string)
+		target, _ := values[4].(dom.Element)
+		m.requests = append(m.requests, Request{
+			Verb:   strings.ToUpper(verb),
+			URL:    m.resolve(path),
+			Target: target,
+			id:     id,
+		})
+	case "settle":
+		for i := range m.requests {
+			if m.requests[i].id == id {
+				m.requests[i].Settled = true
+			}
+		}
+	}
+}
+
+// resolve returns the absolute URL of a path, relative to the window location.
+func (m *Monitor) resolve(path string) string {
+	base, err := url.Parse(m.win.Location().Href())
+	if err != nil {
+		return path
+	}
+	u, err := base.Parse(path)
+	if err != nil {
+		return path
+	}
+	return u.String()
+}
+
+func toInt(v any) int {
+	switch n := v.(type) {
+	case int32:
+		return int(n)
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+// describe describes an element in messages by its id, or tag name.
+func describe(e dom.Element) string {
+	if e == nil {
+		return "<nil>"
+	}
+	if id, ok := e.GetAttribute("id"); ok && id != "" {
+		return "#" + id
+	}
+	return "<" + strings.ToLower(e.TagName()) + ">"
+}
+
+// monitorScript listens to the HTMX events of the document. Requests are
+// identified by the XMLHttpRequest, as events of a request can be dispatched
+// to different elements, e.g., when the element issuing the request is
+// removed by the swap.
+const monitorScript = `(function () {
+	if (window.__shamanHTMX) return;
+	const m = (window.__shamanHTMX = {
+		events: [],
+		ids: new Map(),
+		pending: new Set(),
+		swapping: 0,
+		next: 0,
+		drain() {
+			const events = m.events;
+			m.events = [];
+			return [events, m.pending.size, m.swapping];
+		},
+	});
+	const on = (type, f) => document.addEventListener(type, (e) => f(e.detail || {}));
+	on("htmx:beforeRequest", (d) => {
+		const id = ++m.next;
+		m.ids.set(d.xhr, id);
+		m.pending.add(d.xhr);
+		const path = d.pathInfo ? d.pathInfo.finalRequestPath : d.requestConfig.path;
+		m.events.push(["request", id, d.requestConfig.verb, path, d.target || null]);
+	});
+	on("htmx:afterRequest", (d) => m.pending.delete(d.xhr));
+	on("htmx:afterSwap", () => m.swapping++);
+	on("htmx:afterSettle", (d) => {
+		m.swapping--;
+		m.events.push(["settle", m.ids.get(d.xhr) || 0]);
+	});
+})();`
@@ -0,0 +1,129 @@
+package htmx_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/htmx"
+
+	"github.com/gost-dom/browser"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func cartServer() http.Handler {
+	count := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /htmx.js", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/htmx.js")
+	})
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><script src="/htmx.js"></script></head><body><main>
+			<button hx-post="/cart" hx-target="#cart">Add to cart</button>
+			<div id="cart">Empty</div>
+			<button id="search" hx-get="/search?q=shoes" hx-swap="outerHTML">Search</button>
+		</main></body></html>`)
+	})
+	mux.HandleFunc("POST /cart", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		fmt.Fprintf(w, "%d items", count)
+	})
+	mux.HandleFunc("GET /search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<p id="results">Results for %s</p>`, r.URL.Query().Get("q"))
+	})
+	return mux
+}
+
+func openCart(t *testing.T) (shaman.Scope, html.Window, *htmx.Monitor) {
+	b := browser.New(browser.WithHandler(cartServer()))
+	t.Cleanup(b.Close)
+	win, err := b.Open("http://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return shaman.WindowScope(t, win), win, htmx.New(t, win)
+}
+
+func TestWaitForSwap(t *testing.T) {
+	t.Parallel()
+	scope, win, m := openCart(t)
+	add := scope.Get(shaman.ByRole(ariarole.Button), shaman.ByName("Add to cart"))
+	cart := win.Document().GetElementById("cart")
+
+	add.Click()
+	r := m.WaitForSwap(cart)
+	assert.Equal(t, "POST", r.Verb)
+	assert.Equal(t, "http://example.com/cart", r.URL)
+	assert.True(t, r.Settled)
+	assert.Equal(t, "1 items", cart.TextContent())
+
+	add.Click()
+	m.WaitForSwap(cart)
+	assert.Equal(t, "2 items", cart.TextContent())
+
+	search := scope.Get(shaman.ByRole(ariarole.Button), shaman.ByName("Search"))
+	search.Click()
+	m.WaitForSwap(search)
+	assert.Equal(t, "Results for shoes", win.Document().GetElementById("results").TextContent())
+
+	requests := m.Requests()
+	assert.Len(t, requests, 3)
+	assert.Equal(t, "GET", requests[2].Verb)
+	assert.Equal(t, "http://example.com/search?q=shoes", requests[2].URL)
+	assert.Equal(t, search, requests[2].Target)
+}
+
+func TestWaitForIdle(t *testing.T) {
+	t.Parallel()
+	scope, win, m := openCart(t)
+	scope.Get(shaman.ByRole(ariarole.Button), shaman.ByName("Add to cart")).Click()
+	scope.Get(shaman.ByRole(ariarole.Button), shaman.ByName("Search")).Click()
+	m.WaitForIdle()
+	assert.Equal(t, 0, m.InFlight())
+	for _, r := range m.Requests() {
+		assert.True(t, r.Settled, "Settled: %s", r)
+	}
+	assert.Equal(t, "1 items", win.Document().GetElementById("cart").TextContent())
+}
+
+func TestUpload(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /htmx.js", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/htmx.js")
+	})
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><script src="/htmx.js"></script></head><body>
+			<form aria-label="Upload" hx-post="/upload" hx-encoding="multipart/form-data" hx-target="#result">
+				<label>Title <input type="text" name="title" value="Report" /></label>
+				<label>Attachment <input type="file" name="attachment" /></label>
+				<button>Upload</button>
+			</form>
+			<div id="result"></div>
+		</body></html>`)
+	})
+	mux.HandleFunc("POST /upload", func(w http.ResponseWriter, r *http.Request) {
+		f, h, err := r.FormFile("attachment")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		content, _ := io.ReadAll(f)
+		fmt.Fprintf(w, "%s: %s (%s)", r.FormValue("title"), content, h.Filename)
+	})
+	scope := shaman.OpenHandler(t, mux, "/")
+	m := htmx.New(t, scope.Window())
+	result := scope.Window().Document().GetElementById("result")
+
+	form := scope.Form(shaman.ByName("Upload"))
+	form.FileInput(shaman.ByName("Attachment")).Attach(
+		shaman.File{Name: "hello.txt", ContentType: "text/plain", Content: []byte("Hello")})
+	form.Submit()
+	m.WaitForSwap(result)
+
+	assert.Equal(t, "Report: Hello (hello.txt)", result.TextContent())
+}
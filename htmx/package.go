@@ -0,0 +1,15 @@
+// Package htmx synchronises tests with HTMX requests in a Gost-DOM window.
+//
+// HTMX requests complete asynchronously, and the content is swapped, and
+// settled, after the response is received. A [Monitor] listens to the HTMX
+// events of the window, so a test can wait for a swap, or for all requests to
+// complete, before verifying the content.
+//
+//	m := htmx.New(t, win)
+//	scope.Get(ByRole(ariarole.Button), ByName("Add to cart")).Click()
+//	m.WaitForSwap(win.Document().GetElementById("cart"))
+//	assert.Equal(t, "1 item", scope.Get(ByRole(ariarole.Status)).TextContent())
+//
+// The monitor also records the requests made by HTMX, with the verb, the URL,
+// and the swap target, see [Monitor.Requests].
+package htmx
@@ -0,0 +1,33 @@
+// Package testtb provides a [testing.TB] for testing the helpers of this
+// module, recording the failures they report instead of failing the test.
+package testtb
+
+import (
+	"fmt"
+	"testing"
+)
+
+// RecordingTB records errors and fatal errors instead of failing the test.
+// Other calls are forwarded to the embedded TB, except for log messages, which
+// are discarded.
+type RecordingTB struct {
+	testing.TB
+	Errors []string
+	// Stopped tells if a fatal error was reported, where the real TB would have
+	// stopped the test.
+	Stopped bool
+}
+
+func (t *RecordingTB) Helper()             {}
+func (t *RecordingTB) Logf(string, ...any) {}
+
+func (t *RecordingTB) Errorf(format string, args ...any) {
+	t.Errors = append(t.Errors, fmt.Sprintf(format, args...))
+}
+
+func (t *RecordingTB) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+	t.FailNow()
+}
+
+func (t *RecordingTB) FailNow() { t.Stopped = true }
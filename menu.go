@@ -0,0 +1,253 @@
+package shaman
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+type menuPredicate struct{}
+
+// IsMatch matches elements with the role "menu" or "menubar", as well as menu
+// buttons, i.e., elements with aria-haspopup="menu" or aria-haspopup="true".
+//
+// A menu labelled by its menu button is not matched, as it has the same name as
+// the button, and is found through the button.
+func (menuPredicate) IsMatch(e dom.Element) bool {
+	return (isMenu(e) && !labelledByMenuButton(e)) || isMenuButton(e)
+}
+
+func (menuPredicate) String() string { return "By role: menu, menubar, or menu button" }
+
+func isMenu(e dom.Element) bool {
+	switch ariarole.GetElementRole(e) {
+	case ariarole.Menu, ariarole.Menubar:
+		return true
+	}
+	return false
+}
+
+func labelledByMenuButton(e dom.Element) bool {
+	ids, _ := e.GetAttribute("aria-labelledby")
+	for _, id := range strings.Fields(ids) {
+		if l := e.OwnerDocument().GetElementById(id); l != nil && isMenuButton(l) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMenuButton(e dom.Element) bool {
+	switch popup, _ := e.GetAttribute("aria-haspopup"); popup {
+	case "true", "menu":
+		return true
+	}
+	return false
+}
+
+func isMenuItem(e dom.Element) bool {
+	switch ariarole.GetElementRole(e) {
+	case ariarole.Menuitem, ariarole.Menuitemcheckbox, ariarole.Menuitemradio:
+		return true
+	}
+	return false
+}
+
+// Menu finds the menu matching the options. This can be an element with the
+// role "menu" or "menubar", or a menu button, i.e., an element with
+// aria-haspopup, that displays a menu when clicked.
+//
+// Menus rendered on demand, e.g., row action menus, are found through their
+// menu button.
+//
+//	scope.Menu(ByName("Actions")).Choose("Export", "CSV")
+func (s Scope) Menu(opts ...ElementPredicate) MenuRole {
+	s.t.Helper()
+	opts = append(opts, menuPredicate{})
+	return MenuRole{s.Get(opts...), s.t}
+}
+
+// MenuRole is a helper to interact with a [menu], or a menu button.
+//
+// The menu displayed by a menu button, or by a menu item opening a submenu, is
+// the element referenced by aria-controls; otherwise the menu following the
+// item, or the menu labelled by the item.
+//
+// [menu]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Reference/Roles/menu_role
+type MenuRole struct {
+	html.HTMLElement
+	t testing.TB
+}
+
+func (m MenuRole) unwrap() html.HTMLElement { return m.HTMLElement }
+
+// Open displays the menu of a menu button by clicking the button, unless
+// already expanded. Open has no effect on a menu, or a menubar.
+func (m MenuRole) Open() {
+	if !isMenu(m.HTMLElement) && !popupExpanded(m.HTMLElement) {
+		m.Click()
+	}
+}
+
+// Close simulates the user pressing escape in the menu.
+func (m MenuRole) Close() {
+	if menu := m.menu(); menu != nil {
+		pressKey(menu, "Escape")
+	}
+}
+
+// Items returns the menu items in the menu, excluding items in submenus. The
+// menu of a menu button is opened.
+func (m MenuRole) Items() []html.HTMLElement {
+	m.t.Helper()
+	m.Open()
+	if menu := m.getMenu(); menu != nil {
+		return menuItems(menu)
+	}
+	return nil
+}
+
+// Choose clicks the menu item found by following the path of names, opening
+// each submenu on the path, e.g., Choose("Export", "CSV").
+func (m MenuRole) Choose(path ...string) {
+	m.t.Helper()
+	m.item(path).Click()
+}
+
+// Checked returns whether the menu item found by following the path of names
+// is checked, as indicated by aria-checked. This applies to items with the role
+// "menuitemcheckbox" or "menuitemradio".
+func (m MenuRole) Checked(path ...string) bool {
+	m.t.Helper()
+	v, _ := m.item(path).GetAttribute("aria-checked")
+	return v == "true"
+}
+
+// item returns the menu item at the path, opening all menus leading to it.
+func (m MenuRole) item(path []string) html.HTMLElement {
+	m.t.Helper()
+	if len(path) == 0 {
+		m.t.Fatalf("Menu item path is empty")
+		return nil
+	}
+	m.Open()
+	menu := m.getMenu()
+	if menu == nil {
+		return nil
+	}
+	for i, name := range path {
+		item := m.getItem(menu, name)
+		if i == len(path)-1 {
+			return item
+		}
+		if !popupExpanded(item) {
+			item.Click()
+		}
+		if menu = popupMenu(item); menu == nil {
+			m.t.Fatalf("No submenu for menu item %q: %s", name, item.OuterHTML())
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m MenuRole) getItem(menu dom.Element, name string) html.HTMLElement {
+	m.t.Helper()
+	items := menuItems(menu)
+	i := slices.IndexFunc(items, func(e html.HTMLElement) bool { return ElementName(e) == name })
+	if i == -1 {
+		names := make([]string, len(items))
+		for i, item := range items {
+			names[i] = ElementName(item)
+		}
+		m.t.Fatalf("No menu item named %q. Items: %s", name, strings.Join(names, ", "))
+		return nil
+	}
+	return items[i]
+}
+
+// menu returns the menu element; the element itself for a menu, and the popup
+// of a menu button. Returns nil if the popup isn't displayed.
+func (m MenuRole) menu() dom.Element {
+	if isMenu(m.HTMLElement) {
+		return m.HTMLElement
+	}
+	return popupMenu(m.HTMLElement)
+}
+
+func (m MenuRole) getMenu() dom.Element {
+	m.t.Helper()
+	menu := m.menu()
+	if menu == nil {
+		m.t.Fatalf("No menu displayed by menu button: %s", m.OuterHTML())
+	}
+	return menu
+}
+
+func popupExpanded(e dom.Element) bool {
+	v, _ := e.GetAttribute("aria-expanded")
+	return v == "true"
+}
+
+// popupMenu returns the menu displayed by a menu button or menu item, or nil if
+// none is found, or the menu is hidden, or the element isn't expanded.
+func popupMenu(e dom.Element) dom.Element {
+	menu := popupTarget(e)
+	if menu == nil || ElementHidden(menu) {
+		return nil
+	}
+	if v, ok := e.GetAttribute("aria-expanded"); ok && v != "true" {
+		return nil
+	}
+	return menu
+}
+
+// popupTarget returns the menu of a menu button or menu item, whether or not
+// it is displayed.
+func popupTarget(e dom.Element) dom.Element {
+	doc := e.OwnerDocument()
+	if id, ok := e.GetAttribute("aria-controls"); ok {
+		if menu := doc.GetElementById(id); menu != nil {
+			return menu
+		}
+	}
+	for s := e.NextElementSibling(); s != nil; s = s.NextElementSibling() {
+		if isMenu(s) {
+			return s
+		}
+	}
+	if id := e.ID(); id != "" {
+		for m := range NewScope(nil, doc).All() {
+			if ids, _ := m.GetAttribute("aria-labelledby"); isMenu(m) &&
+				slices.Contains(strings.Fields(ids), id) {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+// menuItems returns the menu items of the menu, excluding items of submenus.
+func menuItems(menu dom.Element) []html.HTMLElement {
+	var res []html.HTMLElement
+	for e := range NewScope(nil, menu).All() {
+		if e, ok := e.(html.HTMLElement); ok && isMenuItem(e) && ownerMenu(e) == menu {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func ownerMenu(e dom.Element) dom.Element {
+	for p := e.ParentElement(); p != nil; p = p.ParentElement() {
+		if isMenu(p) {
+			return p
+		}
+	}
+	return nil
+}
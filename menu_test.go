@@ -0,0 +1,77 @@
+package shaman_test
+
+import (
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/internal/testtb"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMenuButton(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<button id="actions" aria-haspopup="menu" aria-expanded="false">Actions</button>
+		<ul role="menu" aria-labelledby="actions" hidden>
+			<li role="menuitem">Edit</li>
+			<li role="none">
+				<span role="menuitem" aria-haspopup="menu" aria-expanded="true">Export</span>
+				<ul role="menu">
+					<li role="menuitem">CSV</li>
+					<li role="menuitem">PDF</li>
+				</ul>
+			</li>
+			<li role="menuitemcheckbox" aria-checked="true">Show archived</li>
+		</ul>`)
+	button := doc.GetElementById("actions")
+	var chosen string
+	button.AddEventListener("click",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			button.SetAttribute("aria-expanded", "true")
+			button.NextElementSibling().RemoveAttribute("hidden")
+		}))
+	doc.AddEventListener("click",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			chosen = ElementName(e.Target.(dom.Element))
+		}))
+
+	menu := NewScope(t, doc).Menu(ByName("Actions"))
+	assert.Equal(t, []string{"Edit", "Export", "Show archived"}, optionNames(menu.Items()))
+	assert.True(t, menu.Checked("Show archived"))
+
+	menu.Choose("Export", "CSV")
+	assert.Equal(t, "CSV", chosen)
+}
+
+func TestMenuButtonNotOpened(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<button id="actions" aria-haspopup="menu" aria-expanded="false">Actions</button>
+		<ul role="menu" aria-labelledby="actions" hidden>
+			<li role="menuitem">Edit</li>
+		</ul>`)
+	tb := &testtb.RecordingTB{TB: t}
+	menu := NewScope(tb, doc).Menu(ByName("Actions"))
+	assert.Nil(t, menu.Items())
+	assert.Equal(t, []string{
+		`No menu displayed by menu button: <button id="actions" aria-haspopup="menu" aria-expanded="false">Actions</button>`,
+	}, tb.Errors)
+}
+
+func TestMenuClose(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<ul role="menubar" aria-label="Main">
+			<li role="menuitem">File</li>
+		</ul>`)
+	var key string
+	doc.AddEventListener("keydown",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			key = e.Data.(KeyboardEventInit).Key
+		}))
+	NewScope(t, doc).Menu(ByName("Main")).Close()
+	assert.Equal(t, "Escape", key)
+}
@@ -0,0 +1,271 @@
+package shaman
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/gost-dom/browser/html"
+)
+
+// Navigation is the result of a navigation, see [Scope.WaitForNavigation].
+type Navigation struct {
+	URL string
+	// Status is the HTTP status code of the response. For a scope created by
+	// [OpenHandler], it is the status written by the handler, which can be an
+	// error status, in which case the browser keeps displaying the previous
+	// page, unless the response of a multipart form, see [FormRole.Submit].
+	// Otherwise, it is 200, as the browser only displays successful
+	// responses.
+	Status int
+}
+
+// Window returns the window of a scope created by [WindowScope], or nil for
+// other scopes.
+func (s Scope) Window() html.Window {
+	if c, ok := s.root.(windowContainerer); ok {
+		return c.win
+	}
+	return nil
+}
+
+// window returns the window of a window scope. A fatal error is generated if
+// the scope isn't created by [WindowScope].
+func (s Scope) window() html.Window {
+	s.t.Helper()
+	if win := s.Window(); win != nil {
+		return win
+	}
+	s.t.Fatalf("Navigation requires a scope created by WindowScope")
+	return nil
+}
+
+// URL returns the URL of the current page in a window scope.
+func (s Scope) URL() *url.URL {
+	s.t.Helper()
+	win := s.window()
+	if win == nil {
+		return nil
+	}
+	u, err := url.Parse(win.Location().Href())
+	if err != nil {
+		s.t.Fatalf("Invalid window location: %v", err)
+	}
+	return u
+}
+
+// Navigate navigates the window of a window scope to the path, resolved
+// relative to the current URL. A fatal error is generated if the page cannot
+// be loaded.
+func (s Scope) Navigate(path string) {
+	s.t.Helper()
+	win := s.window()
+	if win == nil {
+		return
+	}
+	href := path
+	if u, err := url.Parse(win.Location().Href()); err == nil && u.IsAbs() {
+		if ref, err := u.Parse(path); err == nil {
+			href = ref.String()
+		}
+	}
+	if err := win.Navigate(href); err != nil {
+		s.t.Fatalf("Error navigating to %s: %v", href, err)
+	}
+}
+
+// Back navigates to the previous page in the history of the window.
+func (s Scope) Back() { s.t.Helper(); s.historyGo(-1) }
+
+// Forward navigates to the next page in the history of the window.
+func (s Scope) Forward() { s.t.Helper(); s.historyGo(1) }
+
+// Reload reloads the current page of the window.
+func (s Scope) Reload() { s.t.Helper(); s.historyGo(0) }
+
+func (s Scope) historyGo(delta int) {
+	s.t.Helper()
+	win := s.window()
+	if win == nil {
+		return
+	}
+	if err := win.History().Go(delta); err != nil {
+		s.t.Fatalf("Error navigating history: %v", err)
+	}
+}
+
+// WaitForNavigation runs the action, e.g., clicking a link, or submitting a
+// form, and waits for the window to load a new page. Pending events are
+// processed while waiting, e.g., for a navigation initiated by JavaScript. A
+// fatal error is generated if no navigation happens.
+//
+//	nav := scope.WaitForNavigation(func() {
+//		scope.Get(ByRole(ariarole.Link), ByName("Orders")).Click()
+//	})
+//	assert.Equal(t, "http://example.com/orders", nav.URL)
+func (s Scope) WaitForNavigation(action func()) Navigation {
+	s.t.Helper()
+	win := s.window()
+	if win == nil {
+		return Navigation{}
+	}
+	responses := s.root.(windowContainerer).responses
+	doc := win.Document()
+	seen := responses.len()
+	// The requests of the browser don't tell navigations from requests made by
+	// JavaScript, e.g., an HTMX request failing validation, so only responses
+	// for the new location, or the action of a submitted form, are considered.
+	forms := make(map[string]bool)
+	submit := event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+		if form, ok := e.Target.(html.HTMLFormElement); ok && !e.DefaultPrevented {
+			forms[withoutQuery(form.Action())] = true
+		}
+	})
+	win.AddEventListener("submit", submit)
+	defer win.RemoveEventListener("submit", submit)
+	isNavigation := func(r response) bool {
+		return r.url == win.Location().Href() || forms[withoutQuery(r.url)]
+	}
+	navigated := func() bool {
+		_, failed := responses.failedSince(seen, isNavigation)
+		return win.Document() != doc || failed
+	}
+	action()
+	if !navigated() && win.ScriptContext() != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+		defer cancel()
+		win.Clock().ProcessEventsWhile(ctx, func() bool { return !navigated() })
+	}
+	if win.Document() != doc {
+		status := http.StatusOK
+		if r, ok := responses.lastSince(seen, isNavigation); ok {
+			status = r.status
+		}
+		return Navigation{win.Location().Href(), status}
+	}
+	if r, ok := responses.failedSince(seen, isNavigation); ok {
+		return Navigation{r.url, r.status}
+	}
+	s.t.Fatalf("No navigation. Current URL: %s", win.Location().Href())
+	return Navigation{}
+}
+
+// withoutQuery returns the URL without the query, matching the request of a
+// form submitted with the GET method.
+func withoutQuery(u string) string {
+	res, err := url.Parse(u)
+	if err != nil {
+		return u
+	}
+	res.RawQuery = ""
+	return res.String()
+}
+
+// responseLog records the status of responses from the handler of a window
+// created by [OpenHandler]. A nil log records nothing.
+type responseLog struct {
+	mu        sync.Mutex
+	responses []response
+}
+
+type response struct {
+	url    string
+	status int
+}
+
+func (l *responseLog) add(r response) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.responses = append(l.responses, r)
+}
+
+func (l *responseLog) len() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.responses)
+}
+
+func (l *responseLog) since(i int) []response {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.responses[i:]
+}
+
+// lastSince returns the last response matching the filter since response i.
+func (l *responseLog) lastSince(i int, filter func(response) bool) (response, bool) {
+	since := l.since(i)
+	for i := len(since) - 1; i >= 0; i-- {
+		if filter(since[i]) {
+			return since[i], true
+		}
+	}
+	return response{}, false
+}
+
+// failedSince returns the first response with an error status matching the
+// filter since response i.
+func (l *responseLog) failedSince(i int, filter func(response) bool) (response, bool) {
+	for _, r := range l.since(i) {
+		if r.status >= 400 && filter(r) {
+			return r, true
+		}
+	}
+	return response{}, false
+}
+
+// statusHandler records the response status of the handler in the log. The
+// status is recorded when the header is written, as the browser doesn't read
+// the body of an error response, so the handler may never return.
+type statusHandler struct {
+	h   http.Handler
+	log *responseLog
+}
+
+func (h statusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := r.URL.String()
+	sw := &statusWriter{ResponseWriter: w, onStatus: func(status int) {
+		h.log.add(response{u, status})
+	}}
+	h.h.ServeHTTP(sw, r)
+	sw.record(http.StatusOK)
+}
+
+// statusWriter calls onStatus with the response status, when the header is
+// written.
+type statusWriter struct {
+	http.ResponseWriter
+	onStatus func(status int)
+	recorded bool
+}
+
+func (w *statusWriter) record(status int) {
+	if !w.recorded {
+		w.recorded = true
+		w.onStatus(status)
+	}
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.record(status)
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.record(http.StatusOK)
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped writer, letting http.ResponseController reach
+// optional interfaces, e.g., http.Flusher.
+func (w *statusWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
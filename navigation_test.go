@@ -0,0 +1,95 @@
+package shaman_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/internal/testtb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func navigationServer() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<main><h1>Home</h1>
+				<a href="/orders">Orders</a>
+				<a href="/missing">Missing</a>
+			</main>`)
+		case "/orders":
+			fmt.Fprint(w, `<main><h1>Orders</h1></main>`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestNavigation(t *testing.T) {
+	t.Parallel()
+	scope := OpenHandler(t, navigationServer(), "/")
+	assert.Equal(t, "/", scope.URL().Path)
+
+	nav := scope.WaitForNavigation(func() {
+		scope.Get(ByRole(ariarole.Link), ByName("Orders")).Click()
+	})
+	assert.Equal(t, Navigation{"http://example.com/orders", 200}, nav)
+	assert.Equal(t, "Orders", scope.Get(ByH1).TextContent())
+
+	scope.Back()
+	assert.Equal(t, "/", scope.URL().Path)
+	assert.Equal(t, "Home", scope.Get(ByH1).TextContent())
+
+	scope.Forward()
+	assert.Equal(t, "Orders", scope.Get(ByH1).TextContent())
+
+	scope.Navigate("/")
+	assert.Equal(t, "Home", scope.Get(ByH1).TextContent())
+	scope.Reload()
+	assert.Equal(t, "Home", scope.Get(ByH1).TextContent())
+
+	nav = scope.WaitForNavigation(func() {
+		scope.Get(ByRole(ariarole.Link), ByName("Missing")).Click()
+	})
+	assert.Equal(t, Navigation{"http://example.com/missing", 404}, nav)
+}
+
+func TestWaitForNavigationIgnoresScriptRequests(t *testing.T) {
+	t.Parallel()
+	server := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<main><h1>Home</h1>
+				<button>Validate</button>
+				<form method="post" action="/orders"><button>Save</button></form>
+				<script>
+					document.querySelector("button").addEventListener("click", () => {
+						const xhr = new XMLHttpRequest()
+						xhr.open("POST", "/validate", false)
+						xhr.send()
+					})
+				</script>
+			</main>`)
+		case "/validate", "/orders":
+			http.Error(w, "Invalid", http.StatusUnprocessableEntity)
+		}
+	})
+	tb := &testtb.RecordingTB{TB: t}
+	scope := OpenHandler(tb, server, "/")
+
+	scope.WaitForNavigation(func() {
+		scope.Get(ByRole(ariarole.Button), ByName("Validate")).Click()
+	})
+	if assert.Len(t, tb.Errors, 1, "A failed XHR is not a navigation") {
+		assert.Contains(t, tb.Errors[0], "No navigation")
+	}
+
+	nav := scope.WaitForNavigation(func() {
+		scope.Get(ByRole(ariarole.Button), ByName("Save")).Click()
+	})
+	assert.Equal(t, Navigation{"http://example.com/orders", 422}, nav,
+		"A failed form submission is a navigation")
+}
@@ -0,0 +1,85 @@
+package node
+
+import "github.com/gost-dom/browser/dom"
+
+// FromDOM adapts a Gost-DOM node. Returns nil if n is nil.
+func FromDOM(n dom.Node) Node {
+	switch n := n.(type) {
+	case nil:
+		return nil
+	case dom.Element:
+		return domElement{domNode{n}, n}
+	case dom.Document:
+		return domDocument{domNode{n}, n}
+	}
+	if n.NodeType() == dom.NodeTypeText {
+		return domText{domNode{n}}
+	}
+	return domNode{n}
+}
+
+// FromElement adapts a Gost-DOM element. Returns nil if e is nil.
+func FromElement(e dom.Element) Element {
+	if e == nil {
+		return nil
+	}
+	return domElement{domNode{e}, e}
+}
+
+// ToDOM returns the Gost-DOM node adapted by n, or nil if n isn't a Gost-DOM
+// node.
+func ToDOM(n Node) dom.Node {
+	switch n := n.(type) {
+	case domElement:
+		return n.e
+	case domDocument:
+		return n.d
+	case domText:
+		return n.n
+	case domNode:
+		return n.n
+	}
+	return nil
+}
+
+type domNode struct{ n dom.Node }
+
+func (n domNode) ParentNode() Node    { return FromDOM(n.n.ParentNode()) }
+func (n domNode) TextContent() string { return n.n.TextContent() }
+
+func (n domNode) ChildNodes() []Node {
+	children := n.n.ChildNodes().All()
+	res := make([]Node, len(children))
+	for i, c := range children {
+		res[i] = FromDOM(c)
+	}
+	return res
+}
+
+type domElement struct {
+	domNode
+	e dom.Element
+}
+
+func (e domElement) TagName() string   { return e.e.TagName() }
+func (e domElement) OuterHTML() string { return e.e.OuterHTML() }
+
+func (e domElement) GetAttribute(name string) (string, bool) { return e.e.GetAttribute(name) }
+
+type domDocument struct {
+	domNode
+	d dom.Document
+}
+
+func (d domDocument) GetElementById(id string) Element {
+	return FromElement(d.d.GetElementById(id))
+}
+
+func (d domDocument) QuerySelector(selector string) (Element, error) {
+	e, err := d.d.QuerySelector(selector)
+	return FromElement(e), err
+}
+
+type domText struct{ domNode }
+
+func (domText) IsText() bool { return true }
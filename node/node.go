@@ -0,0 +1,122 @@
+// Package node defines a minimal abstraction of a DOM tree; the part of the DOM
+// needed to determine roles, and accessibility names, and to find elements.
+//
+// Shaman is built on Gost-DOM, and [FromDOM] adapts Gost-DOM nodes. Other DOM
+// implementations can be queried by implementing [Node], [Element], and [Text].
+// See [ariarole.ElementRole], [shaman.AccessibleName], and [shaman.NodeScope].
+// To query server-rendered HTML, use [shaman.ParseHTML].
+//
+// Implementations must be comparable, and return equal values for the same
+// node, i.e., a value type wrapping a pointer to the underlying node.
+package node
+
+import "iter"
+
+// Node is a node in a DOM tree.
+type Node interface {
+	// ParentNode returns the parent; or nil for the root of the tree.
+	ParentNode() Node
+	// ChildNodes returns the children of the node in tree order.
+	ChildNodes() []Node
+	// TextContent returns the concatenated text of all descendant text nodes.
+	TextContent() string
+}
+
+// Element is an element node.
+type Element interface {
+	Node
+	// TagName returns the tag name in upper case for HTML elements, e.g., "DIV".
+	TagName() string
+	GetAttribute(name string) (string, bool)
+	// OuterHTML returns the HTML serialisation of the element, used in error
+	// messages.
+	OuterHTML() string
+}
+
+// Text is a text node.
+type Text interface {
+	Node
+	IsText() bool
+}
+
+// ElementFinder is an optional interface of a root node, e.g., a document,
+// that can find elements by id more efficiently than searching the tree.
+type ElementFinder interface {
+	GetElementById(id string) Element
+}
+
+// SelectorQuerier is an optional interface of a root node, e.g., a document,
+// that can find the first element matching a CSS selector more efficiently than
+// searching the tree. An error is returned for selectors the implementation
+// doesn't support, and the tree is searched instead.
+type SelectorQuerier interface {
+	QuerySelector(selector string) (Element, error)
+}
+
+// HasAttribute returns whether element e has the attribute.
+func HasAttribute(e Element, name string) bool {
+	_, ok := e.GetAttribute(name)
+	return ok
+}
+
+// ParentElement returns the parent of n if it is an element; otherwise nil.
+func ParentElement(n Node) Element {
+	if p, ok := n.ParentNode().(Element); ok {
+		return p
+	}
+	return nil
+}
+
+// Children returns the child elements of n.
+func Children(n Node) []Element {
+	var res []Element
+	for _, c := range n.ChildNodes() {
+		if e, ok := c.(Element); ok {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// Descendants returns an iterator over the descendant elements of n in tree
+// order.
+func Descendants(n Node) iter.Seq[Element] {
+	return func(yield func(Element) bool) { descendants(n, yield) }
+}
+
+func descendants(n Node, yield func(Element) bool) bool {
+	for _, c := range Children(n) {
+		if !yield(c) || !descendants(c, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Root returns the root of the tree containing n.
+func Root(n Node) Node {
+	for p := n.ParentNode(); p != nil; p = p.ParentNode() {
+		n = p
+	}
+	return n
+}
+
+// ElementByID returns the element with the id in the tree containing n; or nil
+// if none is found.
+func ElementByID(n Node, id string) Element {
+	root := Root(n)
+	if f, ok := root.(ElementFinder); ok {
+		return f.GetElementById(id)
+	}
+	if e, ok := root.(Element); ok {
+		if v, _ := e.GetAttribute("id"); v == id {
+			return e
+		}
+	}
+	for e := range Descendants(root) {
+		if v, _ := e.GetAttribute("id"); v == id {
+			return e
+		}
+	}
+	return nil
+}
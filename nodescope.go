@@ -0,0 +1,166 @@
+package shaman
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/gost-dom/shaman/node"
+
+	"github.com/gost-dom/browser/dom"
+)
+
+// A NodePredicate checks if an element of any DOM implementation matches
+// certain criteria, see package [node]. [ByName], [ByRole], and [ByH1] are
+// both an [ElementPredicate] and a NodePredicate.
+//
+// Like ElementPredicate, implementations should also implement [fmt.Stringer].
+type NodePredicate interface{ MatchNode(node.Element) bool }
+
+// NodePredicateFunc wraps a function as a [NodePredicate].
+type NodePredicateFunc func(node.Element) bool
+
+func (f NodePredicateFunc) MatchNode(e node.Element) bool { return f(e) }
+
+// FromNodePredicate returns an [ElementPredicate] for a [NodePredicate],
+// allowing predicates written for any DOM implementation to be used with a
+// [Scope].
+func FromNodePredicate(p NodePredicate) ElementPredicate { return nodePredicate{p} }
+
+type nodePredicate struct{ p NodePredicate }
+
+func (p nodePredicate) IsMatch(e dom.Element) bool { return p.p.MatchNode(node.FromElement(e)) }
+
+func (p nodePredicate) String() string { return fmt.Sprint(p.p) }
+
+// nodePredicates treats multiple predicates as one, like predicates.
+type nodePredicates []NodePredicate
+
+func (o nodePredicates) MatchNode(e node.Element) bool {
+	for _, o := range o {
+		if !o.MatchNode(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// domPredicate matches the Gost-DOM elements adapted by package [node] against
+// element predicates, for a [Scope] to find elements using a [NodeScope].
+type domPredicate predicates
+
+func (p domPredicate) MatchNode(e node.Element) bool {
+	d := domElement(e)
+	return d != nil && predicates(p).IsMatch(d)
+}
+
+func (p domPredicate) String() string { return predicates(p).String() }
+
+// domElement returns the Gost-DOM element adapted by e, or nil if e is nil, or
+// not a Gost-DOM element.
+func domElement(e node.Element) dom.Element {
+	res, _ := node.ToDOM(e).(dom.Element)
+	return res
+}
+
+func (o nodePredicates) String() string {
+	res := make(predicates, len(o))
+	for i, p := range o {
+		res[i] = nodePredicate{p}
+	}
+	return res.String()
+}
+
+// NodeScope is a read-only [Scope] for a DOM tree of any implementation, see
+// package [node]. E.g., to verify the structure of server-side rendered HTML
+// without loading it in a browser. A Scope finds elements using a NodeScope of
+// the Gost-DOM adapter.
+type NodeScope struct {
+	t    testing.TB
+	root node.Node
+}
+
+// NewNodeScope creates a [NodeScope] for the content of root.
+func NewNodeScope(t testing.TB, root node.Node) NodeScope { return NodeScope{t, root} }
+
+// All returns an iterator over all elements in scope. If the scope is an
+// element, the element itself will be included.
+func (s NodeScope) All() iter.Seq[node.Element] {
+	return func(yield func(node.Element) bool) {
+		if s.root == nil {
+			return
+		}
+		if self, ok := s.root.(node.Element); ok {
+			if !yield(self) {
+				return
+			}
+		}
+		for e := range node.Descendants(s.root) {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// FindAll returns a sequence of all elements that match the options.
+func (s NodeScope) FindAll(opts ...NodePredicate) iter.Seq[node.Element] {
+	return func(yield func(node.Element) bool) {
+		for e := range s.All() {
+			if nodePredicates(opts).MatchNode(e) && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns the element that matches the options if any, or nil if none is
+// found. If more than one is found, Fatalf is called.
+func (s NodeScope) Find(opts ...NodePredicate) node.Element {
+	s.t.Helper()
+	// Pull the matches, as Fatalf in the body of a range over a function isn't
+	// reported at the caller of Find.
+	next, stop := iter.Pull(s.FindAll(opts...))
+	defer stop()
+	res, ok := next()
+	if !ok {
+		return nil
+	}
+	if e, ok := next(); ok {
+		s.t.Fatalf(
+			"At least two elements match options: %s\n1st match: %s\n2nd match: %s",
+			nodePredicates(opts),
+			res.OuterHTML(),
+			e.OuterHTML(),
+		)
+		return nil
+	}
+	return res
+}
+
+// Get returns the element that matches the options. Exactly one element is
+// expected to match. If zero, or more than one are found, a fatal error is
+// generated.
+func (s NodeScope) Get(opts ...NodePredicate) node.Element {
+	s.t.Helper()
+	if res := s.Find(opts...); res != nil {
+		return res
+	}
+	s.t.Fatalf("No elements mathing options: %s", nodePredicates(opts))
+	return nil
+}
+
+// Query looks for one element that matches the options. Return ok tells
+// whether an element was found. If more than one are found, a fatal error is
+// generated.
+func (s NodeScope) Query(opts ...NodePredicate) (e node.Element, ok bool) {
+	s.t.Helper()
+	res := s.Find(opts...)
+	return res, res != nil
+}
+
+// Subscope returns a scope for the element matching the options.
+func (s NodeScope) Subscope(opts ...NodePredicate) NodeScope {
+	s.t.Helper()
+	return NewNodeScope(s.t, s.Get(opts...))
+}
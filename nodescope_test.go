@@ -0,0 +1,118 @@
+package shaman_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/node"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeNode is a minimal DOM implementation, verifying that queries don't
+// depend on Gost-DOM.
+type fakeNode struct {
+	parent   *fakeNode
+	tagName  string
+	attrs    map[string]string
+	text     string
+	children []*fakeNode
+}
+
+func fakeElement(tagName string, attrs map[string]string, children ...*fakeNode) *fakeNode {
+	e := &fakeNode{tagName: tagName, attrs: attrs, children: children}
+	for _, c := range children {
+		c.parent = e
+	}
+	return e
+}
+
+func fakeText(text string) *fakeNode { return &fakeNode{text: text} }
+
+func (n *fakeNode) node() node.Node {
+	switch {
+	case n == nil:
+		return nil
+	case n.tagName == "":
+		return fakeTextNode{n}
+	}
+	return fakeElementNode{n}
+}
+
+type fakeTextNode struct{ *fakeNode }
+
+func (fakeTextNode) IsText() bool { return true }
+
+type fakeElementNode struct{ *fakeNode }
+
+func (e fakeElementNode) TagName() string { return e.tagName }
+
+func (e fakeElementNode) GetAttribute(name string) (string, bool) {
+	v, ok := e.attrs[name]
+	return v, ok
+}
+
+func (e fakeElementNode) OuterHTML() string { return fmt.Sprintf("<%s>", e.tagName) }
+
+func (n *fakeNode) ParentNode() node.Node {
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent.node()
+}
+
+func (n *fakeNode) ChildNodes() []node.Node {
+	res := make([]node.Node, len(n.children))
+	for i, c := range n.children {
+		res[i] = c.node()
+	}
+	return res
+}
+
+func (n *fakeNode) TextContent() string {
+	var b strings.Builder
+	b.WriteString(n.text)
+	for _, c := range n.children {
+		b.WriteString(c.TextContent())
+	}
+	return b.String()
+}
+
+func TestNodeScope(t *testing.T) {
+	t.Parallel()
+	root := fakeElement("BODY", nil,
+		fakeElement("MAIN", nil,
+			fakeElement("H1", nil, fakeText("Orders")),
+			fakeElement("LABEL", map[string]string{"for": "search"}, fakeText("Search")),
+			fakeElement("INPUT", map[string]string{"id": "search"}),
+			fakeElement("LABEL", nil,
+				fakeText("Status "),
+				fakeElement("SELECT", nil, fakeElement("OPTION", nil, fakeText("Open"))),
+			),
+			fakeElement("DIV", map[string]string{"role": "button"}, fakeText("Save")),
+		),
+	)
+	scope := NewNodeScope(t, root.node())
+
+	assert.Equal(t, "Orders", scope.Get(ByH1).TextContent())
+	main := scope.Subscope(ByRole(ariarole.Main))
+	assert.Equal(t, "INPUT", main.Get(ByRole(ariarole.Textbox), ByName("Search")).TagName())
+	assert.Equal(t, "SELECT", main.Get(ByRole(ariarole.Combobox), ByName("Status")).TagName())
+	assert.Equal(t, "DIV", main.Get(ByRole(ariarole.Button), ByName("Save")).TagName())
+
+	_, ok := scope.Query(ByRole(ariarole.Navigation))
+	assert.False(t, ok)
+}
+
+func TestFromNodePredicate(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `<body><button>Save</button><button>Cancel</button></body>`)
+	saveButton := NodePredicateFunc(func(e node.Element) bool {
+		return AccessibleName(e) == "Save"
+	})
+	button := NewScope(t, doc).Get(FromNodePredicate(saveButton))
+	assert.Equal(t, "Save", button.TextContent())
+}
@@ -0,0 +1,187 @@
+package shaman
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ParseHTML parses HTML, e.g., the body of an [httptest.ResponseRecorder], and
+// returns a [Scope] for the content. This supports verifying the output of an
+// HTTP handler, e.g., an HTMX partial, without starting a browser. JavaScript
+// is not executed, and <script> elements are removed.
+//
+// The source can be a string, a []byte, or an [io.Reader]. If the source is a
+// complete document, i.e., starts with a doctype, or an <html>, <head>, or
+// <body> tag, the scope is the document. Otherwise, the source is a fragment,
+// and the scope is the <body> element containing it, so audits don't apply
+// page level rules, e.g., a page must have a main landmark. Fragments of
+// elements only allowed in certain parents are parsed in a fitting parent,
+// which is the scope, e.g., the rows of a <tr> partial are in a <tbody>.
+//
+// [httptest.ResponseRecorder]: https://pkg.go.dev/net/http/httptest#ResponseRecorder
+func ParseHTML(t testing.TB, source any) Scope {
+	t.Helper()
+	var src []byte
+	switch s := source.(type) {
+	case string:
+		src = []byte(s)
+	case []byte:
+		src = s
+	case io.Reader:
+		var err error
+		if src, err = io.ReadAll(s); err != nil {
+			t.Fatalf("ParseHTML: error reading source: %v", err)
+			return Scope{}
+		}
+	default:
+		t.Fatalf("ParseHTML: unsupported source type: %T", source)
+		return Scope{}
+	}
+	if isDocument(src) {
+		return NewScope(t, parseDocument(t, src))
+	}
+	return parseFragment(t, src)
+}
+
+// fragmentContexts are the elements a fragment is parsed in, by the first tag
+// of the fragment, for elements only allowed in certain parents, e.g., a <tr>
+// partial is parsed in a <tbody>. Other fragments are parsed in the <body>.
+var fragmentContexts = map[string][]string{
+	"caption":  {"table"},
+	"colgroup": {"table"},
+	"thead":    {"table"},
+	"tbody":    {"table"},
+	"tfoot":    {"table"},
+	"col":      {"table", "colgroup"},
+	"tr":       {"table", "tbody"},
+	"td":       {"table", "tbody", "tr"},
+	"th":       {"table", "tbody", "tr"},
+	"option":   {"select"},
+	"optgroup": {"select"},
+}
+
+// parseDocument loads a complete HTML document, without scripts, in a window.
+func parseDocument(t testing.TB, src []byte) dom.Document {
+	t.Helper()
+	// The browser requires a script engine to load <script> elements, so they
+	// are removed before loading the HTML.
+	tree, err := nethtml.Parse(bytes.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseHTML: error parsing HTML: %v", err)
+		return nil
+	}
+	removeScripts(tree)
+	var b bytes.Buffer
+	if err := nethtml.Render(&b, tree); err != nil {
+		t.Fatalf("ParseHTML: error rendering HTML: %v", err)
+		return nil
+	}
+	return loadDocument(t, &b)
+}
+
+// parseFragment parses a fragment in the context of its first tag, and returns
+// the scope of the context element.
+func parseFragment(t testing.TB, src []byte) Scope {
+	t.Helper()
+	contexts := fragmentContexts[firstTag(src)]
+	context := &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body}
+	if len(contexts) > 0 {
+		tag := contexts[len(contexts)-1]
+		context = &nethtml.Node{Type: nethtml.ElementNode, Data: tag, DataAtom: atom.Lookup([]byte(tag))}
+	}
+	nodes, err := nethtml.ParseFragment(bytes.NewReader(src), context)
+	if err != nil {
+		t.Fatalf("ParseHTML: error parsing HTML: %v", err)
+		return Scope{}
+	}
+	var b bytes.Buffer
+	b.WriteString("<body>")
+	for _, tag := range contexts {
+		b.WriteString("<" + tag + ">")
+	}
+	for _, n := range nodes {
+		if n.Type == nethtml.ElementNode && n.Data == "script" {
+			continue
+		}
+		removeScripts(n)
+		if err := nethtml.Render(&b, n); err != nil {
+			t.Fatalf("ParseHTML: error rendering HTML: %v", err)
+			return Scope{}
+		}
+	}
+	for _, tag := range slices.Backward(contexts) {
+		b.WriteString("</" + tag + ">")
+	}
+	b.WriteString("</body>")
+	doc := loadDocument(t, &b)
+	if doc == nil {
+		return Scope{}
+	}
+	var root dom.Element = doc.Body()
+	for range contexts {
+		root = root.FirstElementChild()
+	}
+	return NewScope(t, root)
+}
+
+func loadDocument(t testing.TB, r io.Reader) dom.Document {
+	t.Helper()
+	win, err := html.NewWindowReader(r)
+	if err != nil {
+		t.Fatalf("ParseHTML: error parsing HTML: %v", err)
+		return nil
+	}
+	return win.Document()
+}
+
+// isDocument returns whether the source is a complete HTML document, rather
+// than a fragment.
+func isDocument(src []byte) bool {
+	switch firstTag(src) {
+	case "!doctype", "html", "head", "body":
+		return true
+	}
+	return false
+}
+
+// firstTag returns the name of the first tag of the source, or "!doctype" if
+// it starts with a doctype. It returns empty string if the source starts with
+// text.
+func firstTag(src []byte) string {
+	z := nethtml.NewTokenizer(bytes.NewReader(src))
+	for {
+		switch z.Next() {
+		case nethtml.ErrorToken:
+			return ""
+		case nethtml.DoctypeToken:
+			return "!doctype"
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			name, _ := z.TagName()
+			return string(name)
+		case nethtml.TextToken:
+			if strings.TrimSpace(string(z.Text())) != "" {
+				return ""
+			}
+		}
+	}
+}
+
+func removeScripts(n *nethtml.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if c.Type == nethtml.ElementNode && c.Data == "script" {
+			n.RemoveChild(c)
+		} else {
+			removeScripts(c)
+		}
+		c = next
+	}
+}
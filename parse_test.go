@@ -0,0 +1,66 @@
+package shaman_test
+
+import (
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func firstElement(s Scope) dom.Element {
+	next, stop := iter.Pull(s.All())
+	defer stop()
+	e, _ := next()
+	return e
+}
+
+func TestParseHTMLFragment(t *testing.T) {
+	t.Parallel()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`
+			<ul aria-label="Orders">
+				<li>Order 1 <button>Delete</button></li>
+			</ul>
+			<script>document.body.innerHTML = ""</script>`))
+	})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/orders", nil))
+
+	scope := ParseHTML(t, rec.Body)
+	assert.Equal(t, "BODY", firstElement(scope).TagName(), "The scope of a fragment is the body")
+	list := scope.Get(ByRole(ariarole.List), ByName("Orders"))
+	assert.Equal(t, "UL", list.TagName())
+	assert.NotNil(t, scope.Get(ByRole(ariarole.Button), ByName("Delete")),
+		"Scripts are not executed")
+}
+
+func TestParseHTMLDocument(t *testing.T) {
+	t.Parallel()
+	scope := ParseHTML(t, `<!DOCTYPE html>
+		<html><body><main><h1>Orders</h1></main></body></html>`)
+	assert.Equal(t, "HTML", firstElement(scope).TagName(), "The scope of a document is the document")
+	assert.Equal(t, "Orders", scope.Get(ByH1).TextContent())
+}
+
+func TestParseHTMLTableRowFragment(t *testing.T) {
+	t.Parallel()
+	scope := ParseHTML(t, `
+		<tr><td>Order 1</td><td><button>Delete</button></td></tr>
+		<tr><td>Order 2</td><td><button>Delete</button></td></tr>`)
+	assert.Equal(t, "TBODY", firstElement(scope).TagName(), "Rows are parsed in a <tbody>")
+	rows := slices.Collect(scope.FindAll(ByRole(ariarole.Row)))
+	if assert.Len(t, rows, 2) {
+		assert.Equal(t, "Order 1", ElementName(NewScope(t, rows[0]).Get(ByRole(ariarole.Cell), ByName("Order 1"))))
+	}
+
+	scope = ParseHTML(t, `<option>Denmark</option><option>Sweden</option>`)
+	assert.Equal(t, "SELECT", firstElement(scope).TagName())
+	assert.NotNil(t, scope.Get(ByRole(ariarole.Option), ByName("Sweden")))
+}
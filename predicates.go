@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/node"
 
 	"github.com/gost-dom/browser/dom"
 )
@@ -16,6 +17,8 @@ type ByName string
 
 func (n ByName) IsMatch(e dom.Element) bool { return GetName(e) == string(n) }
 
+func (n ByName) MatchNode(e node.Element) bool { return AccessibleName(e) == string(n) }
+
 func (n ByName) String() string { return fmt.Sprintf("By accessibility name: %s", string(n)) }
 
 // An [ElementPredicate] that matches elements by their [ARIA role].
@@ -29,6 +32,10 @@ func (r ByRole) IsMatch(
 	return ariarole.GetElementRole(e) == ariarole.Role(r)
 }
 
+func (r ByRole) MatchNode(e node.Element) bool {
+	return ariarole.ElementRole(e) == ariarole.Role(r)
+}
+
 func (r ByRole) String() string { return fmt.Sprintf("By role: %s", string(r)) }
 
 type byH1Predicate struct{}
@@ -45,4 +52,6 @@ var ByH1 = byH1Predicate{}
 
 func (s byH1Predicate) IsMatch(e dom.Element) bool { return e.TagName() == "H1" }
 
+func (s byH1Predicate) MatchNode(e node.Element) bool { return e.TagName() == "H1" }
+
 func (s byH1Predicate) String() string { return "Main heading (<h1>)" }
@@ -12,3 +12,6 @@ type ByRole = shaman.ByRole
 
 // ByH1 re-exports [shamab.ByH1]
 var ByH1 = shaman.ByH1
+
+// ByCell re-exports [shaman.ByCell]
+var ByCell = shaman.ByCell
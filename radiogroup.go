@@ -0,0 +1,158 @@
+package shaman
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+type radioGroupPredicate struct{}
+
+// IsMatch matches elements with the role "radiogroup", as well as <fieldset>
+// elements containing radio buttons, as the <fieldset> element is the native
+// way of grouping radio buttons.
+func (radioGroupPredicate) IsMatch(e dom.Element) bool {
+	switch ariarole.GetElementRole(e) {
+	case ariarole.Radiogroup:
+		return true
+	case ariarole.Group:
+		r, _ := e.QuerySelector("input[type='radio']")
+		return r != nil
+	}
+	return false
+}
+
+func (radioGroupPredicate) String() string {
+	return "By role: radiogroup, or <fieldset> with radio buttons"
+}
+
+// RadioGroup finds a group of radio buttons matching the options, i.e., an
+// element with the role "radiogroup", or a <fieldset> containing radio
+// buttons. The name of a <fieldset> is the text of its <legend>.
+func (s Scope) RadioGroup(opts ...ElementPredicate) RadioGroupRole {
+	s.t.Helper()
+	opts = append(opts, radioGroupPredicate{})
+	return RadioGroupRole{s.Get(opts...), s.t}
+}
+
+// RadioGroupRole is a helper to interact with a group of radio buttons, where
+// only one can be checked at a time.
+type RadioGroupRole struct {
+	html.HTMLElement
+	t testing.TB
+}
+
+func (g RadioGroupRole) unwrap() html.HTMLElement { return g.HTMLElement }
+
+// Options returns the radio buttons in the group.
+func (g RadioGroupRole) Options() []html.HTMLElement {
+	var res []html.HTMLElement
+	for e := range NewScope(g.t, g.HTMLElement).FindAll(ByRole(ariarole.Radio)) {
+		if e, ok := e.(html.HTMLElement); ok {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// Selected returns the checked radio button, or nil if none are checked.
+func (g RadioGroupRole) Selected() html.HTMLElement {
+	for _, r := range g.Options() {
+		if radioChecked(r) {
+			return r
+		}
+	}
+	return nil
+}
+
+// Choose checks the radio button with the accessibility name.
+//
+// A native radio button is checked, other radio buttons with the same name are
+// unchecked, and "input" and "change" events are dispatched. A custom radio
+// button, e.g., <div role="radio">, is clicked.
+func (g RadioGroupRole) Choose(name string) {
+	g.t.Helper()
+	opts := g.Options()
+	i := slices.IndexFunc(opts, func(e html.HTMLElement) bool { return ElementName(e) == name })
+	if i == -1 {
+		names := make([]string, len(opts))
+		for i, o := range opts {
+			names[i] = ElementName(o)
+		}
+		g.t.Fatalf("No radio button named %q. Options: %s", name, strings.Join(names, ", "))
+		return
+	}
+	g.check(opts, opts[i])
+}
+
+// Next simulates pressing the down arrow key, while the group has focus. For
+// native radio buttons, this checks the next radio button in the group,
+// wrapping around to the first. Custom radio groups must implement their own
+// keyboard handling.
+func (g RadioGroupRole) Next() { g.navigate("ArrowDown", 1) }
+
+// Previous simulates pressing the up arrow key, while the group has focus. For
+// native radio buttons, this checks the previous radio button in the group,
+// wrapping around to the last. Custom radio groups must implement their own
+// keyboard handling.
+func (g RadioGroupRole) Previous() { g.navigate("ArrowUp", -1) }
+
+func (g RadioGroupRole) navigate(key string, delta int) {
+	g.t.Helper()
+	opts := g.Options()
+	if len(opts) == 0 {
+		g.t.Fatalf("Radio group has no radio buttons: %s", g.OuterHTML())
+		return
+	}
+	i := slices.IndexFunc(opts, radioChecked)
+	current := opts[max(i, 0)]
+	current.Focus()
+	if !pressKey(current, key) {
+		return
+	}
+	if _, ok := current.(html.HTMLInputElement); !ok {
+		return
+	}
+	next := opts[(i+delta+len(opts))%len(opts)]
+	if i == -1 {
+		next = opts[0]
+	}
+	next.Focus()
+	g.check(opts, next)
+}
+
+// check checks radio button r. For native radio buttons, other radio buttons in
+// opts with the same name are unchecked.
+func (g RadioGroupRole) check(opts []html.HTMLElement, r html.HTMLElement) {
+	if radioChecked(r) {
+		return
+	}
+	input, ok := r.(html.HTMLInputElement)
+	if !ok {
+		r.Click()
+		return
+	}
+	for _, o := range opts {
+		if o, ok := o.(html.HTMLInputElement); ok && o.Name() == input.Name() {
+			setChecked(o, false)
+		}
+	}
+	setChecked(input, true)
+	dispatchEvent(input, "input")
+	dispatchEvent(input, "change")
+}
+
+// radioChecked returns whether a radio button is checked. For an <input>
+// element, this is the checkedness; otherwise the aria-checked attribute.
+func radioChecked(r html.HTMLElement) bool {
+	if input, ok := r.(html.HTMLInputElement); ok {
+		return inputChecked(input)
+	}
+	v, _ := r.GetAttribute("aria-checked")
+	return v == "true"
+}
@@ -0,0 +1,77 @@
+package shaman_test
+
+import (
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRadioGroupFieldset(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<fieldset>
+			<legend>Shipping method</legend>
+			<label><input type="radio" name="shipping" value="std" /> Standard</label>
+			<label><input type="radio" name="shipping" value="exp" /> Express</label>
+			<label><input type="radio" name="shipping" value="pickup" /> Pickup</label>
+		</fieldset>
+		<fieldset>
+			<legend>Payment</legend>
+			<label><input type="radio" name="payment" value="card" /> Card</label>
+		</fieldset>`)
+	var changes int
+	doc.AddEventListener("change",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) { changes++ }))
+
+	scope := NewScope(t, doc)
+	group := scope.RadioGroup(ByName("Shipping method"))
+	assert.Len(t, group.Options(), 3)
+	assert.Nil(t, group.Selected())
+
+	group.Choose("Express")
+	assert.Equal(t, "Express", ElementName(group.Selected()))
+	assert.Equal(t, 1, changes)
+
+	group.Choose("Standard")
+	assert.Equal(t, "Standard", ElementName(group.Selected()))
+	assert.Equal(t, 2, changes)
+
+	scope.RadioGroup(ByName("Payment")).Choose("Card")
+	assert.Equal(t, "Standard", ElementName(group.Selected()),
+		"Choosing in another group doesn't affect the selection")
+
+	t.Run("Arrow key navigation", func(t *testing.T) {
+		group.Next()
+		assert.Equal(t, "Express", ElementName(group.Selected()))
+		group.Next()
+		group.Next()
+		assert.Equal(t, "Standard", ElementName(group.Selected()), "Wraps to first")
+		group.Previous()
+		assert.Equal(t, "Pickup", ElementName(group.Selected()), "Wraps to last")
+	})
+}
+
+func TestRadioGroupCustom(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<div role="radiogroup" aria-label="Size">
+			<div role="radio" aria-checked="true">Small</div>
+			<div role="radio" aria-checked="false">Large</div>
+		</div>`)
+	group := NewScope(t, doc).RadioGroup(ByName("Size"))
+	for _, r := range group.Options() {
+		r.AddEventListener("click",
+			event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+				for _, o := range group.Options() {
+					o.SetAttribute("aria-checked", "false")
+				}
+				r.SetAttribute("aria-checked", "true")
+			}))
+	}
+	assert.Equal(t, "Small", ElementName(group.Selected()))
+	group.Choose("Large")
+	assert.Equal(t, "Large", ElementName(group.Selected()))
+}
@@ -0,0 +1,239 @@
+package shaman
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+// Slider finds the element with the role "slider" matching the options, e.g.,
+// <input type="range">.
+func (s Scope) Slider(opts ...ElementPredicate) RangeRole {
+	s.t.Helper()
+	return s.rangeRole(ariarole.Slider, opts)
+}
+
+// Spinbutton finds the element with the role "spinbutton" matching the
+// options, e.g., <input type="number">.
+func (s Scope) Spinbutton(opts ...ElementPredicate) RangeRole {
+	s.t.Helper()
+	return s.rangeRole(ariarole.Spinbutton, opts)
+}
+
+// Progressbar finds the element with the role "progressbar" matching the
+// options, e.g., <progress>.
+func (s Scope) Progressbar(opts ...ElementPredicate) RangeRole {
+	s.t.Helper()
+	return s.rangeRole(ariarole.Progressbar, opts)
+}
+
+// Meter finds the element with the role "meter" matching the options, e.g.,
+// <meter>.
+func (s Scope) Meter(opts ...ElementPredicate) RangeRole {
+	s.t.Helper()
+	return s.rangeRole(ariarole.Meter, opts)
+}
+
+func (s Scope) rangeRole(role ariarole.Role, opts []ElementPredicate) RangeRole {
+	s.t.Helper()
+	opts = append(opts, ByRole(role))
+	return RangeRole{s.Get(opts...), s.t}
+}
+
+// RangeRole is a helper to interact with elements representing a value within
+// a range; sliders, spin buttons, progress bars, and meters.
+//
+// For native elements, the value and bounds are read from the value, min, and
+// max attributes. For custom elements, they are read from aria-valuenow,
+// aria-valuemin, and aria-valuemax.
+type RangeRole struct {
+	html.HTMLElement
+	t testing.TB
+}
+
+func (r RangeRole) unwrap() html.HTMLElement { return r.HTMLElement }
+
+func (r RangeRole) native() bool {
+	switch r.TagName() {
+	case "INPUT", "PROGRESS", "METER":
+		return true
+	}
+	return false
+}
+
+func (r RangeRole) readonly() bool {
+	switch ariarole.GetElementRole(r) {
+	case ariarole.Progressbar, ariarole.Meter:
+		return true
+	}
+	return false
+}
+
+// Value returns the current value. Returns NaN if the element has no value,
+// e.g., an indeterminate progress bar.
+func (r RangeRole) Value() float64 {
+	if input, ok := r.HTMLElement.(html.HTMLInputElement); ok {
+		return parseFloat(input.Value(), math.NaN())
+	}
+	if r.native() {
+		return floatAttribute(r, "value", math.NaN())
+	}
+	return floatAttribute(r, "aria-valuenow", math.NaN())
+}
+
+// Min returns the minimum allowed value. Returns -Inf if there is no minimum.
+func (r RangeRole) Min() float64 {
+	switch r.TagName() {
+	case "INPUT":
+		return floatAttribute(r, "min", r.defaultMin())
+	case "PROGRESS", "METER":
+		return floatAttribute(r, "min", 0)
+	}
+	return floatAttribute(r, "aria-valuemin", r.defaultMin())
+}
+
+// Max returns the maximum allowed value. Returns +Inf if there is no maximum.
+func (r RangeRole) Max() float64 {
+	switch r.TagName() {
+	case "INPUT":
+		return floatAttribute(r, "max", r.defaultMax())
+	case "PROGRESS", "METER":
+		return floatAttribute(r, "max", 1)
+	}
+	return floatAttribute(r, "aria-valuemax", r.defaultMax())
+}
+
+func (r RangeRole) defaultMin() float64 {
+	if ariarole.GetElementRole(r) == ariarole.Spinbutton {
+		return math.Inf(-1)
+	}
+	return 0
+}
+
+func (r RangeRole) defaultMax() float64 {
+	if ariarole.GetElementRole(r) == ariarole.Spinbutton {
+		return math.Inf(1)
+	}
+	return 100
+}
+
+// ValueText returns the human readable value, as announced by a screen
+// reader; the value of aria-valuetext if present, otherwise the value.
+func (r RangeRole) ValueText() string {
+	if text, ok := r.GetAttribute("aria-valuetext"); ok {
+		return text
+	}
+	if v := r.Value(); !math.IsNaN(v) {
+		return formatFloat(v)
+	}
+	return ""
+}
+
+// SetValue changes the value, generating a fatal error if the value is out of
+// bounds, or the element is read-only, like a progress bar.
+//
+// The value of a native <input> is set directly, dispatching "input" and
+// "change" events. A custom element receives keyboard input, Home and End for
+// the bounds, otherwise arrow keys, until it has the desired value.
+func (r RangeRole) SetValue(v float64) {
+	r.t.Helper()
+	if r.readonly() {
+		r.t.Fatalf("Cannot set value of read-only element: %s", r.OuterHTML())
+		return
+	}
+	if v < r.Min() || v > r.Max() {
+		r.t.Fatalf(
+			"Value %s out of bounds [%s, %s]: %s",
+			formatFloat(v), formatFloat(r.Min()), formatFloat(r.Max()), r.OuterHTML(),
+		)
+		return
+	}
+	if input, ok := r.HTMLElement.(html.HTMLInputElement); ok {
+		input.SetValue(formatFloat(v))
+		dispatchEvent(input, "input")
+		dispatchEvent(input, "change")
+		return
+	}
+	r.Focus()
+	switch {
+	case v == r.Min() && r.Value() != v:
+		r.pressKey("Home", -1)
+	case v == r.Max() && r.Value() != v:
+		r.pressKey("End", 1)
+	}
+	for r.Value() < v {
+		r.pressKey("ArrowUp", 1)
+	}
+	for r.Value() > v {
+		r.pressKey("ArrowDown", -1)
+	}
+	if r.Value() != v {
+		r.t.Fatalf("Value %s cannot be reached using arrow keys: %s", formatFloat(v), r.OuterHTML())
+	}
+}
+
+// Increment simulates pressing the up arrow key. For a native <input>, the
+// value is increased by the step, without exceeding the maximum.
+func (r RangeRole) Increment() {
+	r.t.Helper()
+	r.step("ArrowUp", 1)
+}
+
+// Decrement simulates pressing the down arrow key. For a native <input>, the
+// value is decreased by the step, without exceeding the minimum.
+func (r RangeRole) Decrement() {
+	r.t.Helper()
+	r.step("ArrowDown", -1)
+}
+
+func (r RangeRole) step(key string, direction float64) {
+	r.t.Helper()
+	if r.readonly() {
+		r.t.Fatalf("Cannot change value of read-only element: %s", r.OuterHTML())
+		return
+	}
+	r.Focus()
+	input, ok := r.HTMLElement.(html.HTMLInputElement)
+	if !pressKey(r, key) || !ok {
+		return
+	}
+	value := r.Value()
+	if math.IsNaN(value) {
+		value = 0
+	}
+	value = math.Min(math.Max(value+direction*floatAttribute(r, "step", 1), r.Min()), r.Max())
+	input.SetValue(formatFloat(value))
+	dispatchEvent(input, "input")
+	dispatchEvent(input, "change")
+}
+
+// pressKey presses a key, generating a fatal error unless the value changed in
+// the expected direction, avoiding an infinite loop when stepping towards a
+// value.
+func (r RangeRole) pressKey(key string, direction float64) {
+	r.t.Helper()
+	before := r.Value()
+	pressKey(r, key)
+	if after := r.Value(); math.IsNaN(after) || (after-before)*direction <= 0 {
+		r.t.Fatalf("Value didn't change as expected when pressing %s: %s", key, r.OuterHTML())
+	}
+}
+
+func floatAttribute(e dom.Element, name string, def float64) float64 {
+	v, _ := e.GetAttribute(name)
+	return parseFloat(v, def)
+}
+
+func parseFloat(s string, def float64) float64 {
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return v
+	}
+	return def
+}
+
+func formatFloat(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
@@ -0,0 +1,75 @@
+package shaman_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeNative(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<label for="qty">Quantity</label>
+		<input id="qty" type="number" min="1" max="10" value="2" />
+		<label for="volume">Volume</label>
+		<input id="volume" type="range" step="5" value="50" />
+		<label for="upload">Upload</label>
+		<progress id="upload" max="200" value="50"></progress>
+		<progress aria-label="Loading"></progress>`)
+	scope := NewScope(t, doc)
+
+	qty := scope.Spinbutton(ByName("Quantity"))
+	assert.Equal(t, 2.0, qty.Value())
+	qty.SetValue(4)
+	assert.Equal(t, 4.0, qty.Value())
+	qty.Increment()
+	assert.Equal(t, "5", qty.ValueText())
+
+	volume := scope.Slider(ByName("Volume"))
+	assert.Equal(t, 100.0, volume.Max(), "Default max of a range input")
+	volume.Decrement()
+	assert.Equal(t, 45.0, volume.Value())
+
+	upload := scope.Progressbar(ByName("Upload"))
+	assert.Equal(t, 50.0, upload.Value())
+	assert.Equal(t, 200.0, upload.Max())
+	assert.True(t, math.IsNaN(scope.Progressbar(ByName("Loading")).Value()),
+		"Indeterminate progress bar has no value")
+}
+
+func TestRangeCustomSlider(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<div role="slider" aria-label="Temperature" tabindex="0"
+			aria-valuemin="10" aria-valuemax="30" aria-valuenow="20"
+			aria-valuetext="20 degrees"></div>`)
+	slider := NewScope(t, doc).Slider(ByName("Temperature"))
+	slider.AddEventListener("keydown",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			v := slider.Value()
+			switch e.Data.(KeyboardEventInit).Key {
+			case "ArrowUp":
+				v++
+			case "ArrowDown":
+				v--
+			case "Home":
+				v = slider.Min()
+			case "End":
+				v = slider.Max()
+			}
+			slider.SetAttribute("aria-valuenow", formatValue(v))
+		}))
+
+	assert.Equal(t, "20 degrees", slider.ValueText())
+	slider.SetValue(23)
+	assert.Equal(t, 23.0, slider.Value())
+	slider.SetValue(10)
+	assert.Equal(t, 10.0, slider.Value())
+}
+
+func formatValue(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
@@ -0,0 +1,247 @@
+package shaman
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// RecordedRequest is a request received by a [RequestRecorder].
+type RecordedRequest struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	// Form contains the parsed form data of the body, and the query string,
+	// like [http.Request.Form].
+	Form url.Values
+	Body []byte
+	// Status is the HTTP status code of the response, or 0 if the handler
+	// hasn't yet written the response.
+	Status int
+}
+
+func (r RecordedRequest) String() string {
+	res := fmt.Sprintf("%s %s", r.Method, r.URL.RequestURI())
+	if r.Method != http.MethodGet && len(r.Form) > 0 {
+		res += " " + r.Form.Encode()
+	}
+	if r.Status != 0 {
+		res += fmt.Sprintf(" (%d)", r.Status)
+	}
+	return res
+}
+
+// RequestRecorder is an [http.Handler] recording the requests to the handler
+// it wraps, making it possible to verify the requests triggered by
+// interactions, e.g., that submitting a form posts the expected values.
+//
+//	rec := shaman.NewRequestRecorder(t, server)
+//	scope := shaman.OpenHandler(t, rec, "/products/42")
+//	scope.Textbox(ByName("Quantity")).Write("2")
+//	scope.Get(ByRole(ariarole.Button), ByName("Add to cart")).Click()
+//	rec.ExpectRequest("POST", "/cart").WithForm("qty", "2")
+type RequestRecorder struct {
+	t            testing.TB
+	h            http.Handler
+	mu           sync.Mutex
+	requests     []*RecordedRequest
+	expectations []*ExpectedRequest
+}
+
+// NewRequestRecorder creates a recorder for requests to the handler.
+// Expectations that are not met generate errors when the test completes.
+func NewRequestRecorder(t testing.TB, h http.Handler) *RequestRecorder {
+	r := &RequestRecorder{t: t, h: h}
+	t.Cleanup(r.verify)
+	return r
+}
+
+func (r *RequestRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rec := &RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL,
+		Header: req.Header.Clone(),
+	}
+	if req.Body != nil {
+		rec.Body, _ = io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewReader(rec.Body))
+	}
+	rec.Form = parseForm(req, rec.Body)
+	r.mu.Lock()
+	r.requests = append(r.requests, rec)
+	r.mu.Unlock()
+
+	sw := &statusWriter{ResponseWriter: w, onStatus: func(status int) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		rec.Status = status
+	}}
+	r.h.ServeHTTP(sw, req)
+	sw.record(http.StatusOK)
+}
+
+// parseForm parses the form data of the request body, without consuming the
+// body of the request.
+func parseForm(req *http.Request, body []byte) url.Values {
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	// Errors are ignored, as a request doesn't need to have form data. The
+	// form still contains the values parsed before the error, e.g., the query.
+	clone.ParseMultipartForm(32 << 20)
+	if clone.MultipartForm != nil {
+		clone.MultipartForm.RemoveAll()
+	}
+	return clone.Form
+}
+
+// Requests returns the requests received, in the order they were received.
+func (r *RequestRecorder) Requests() []RecordedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]RecordedRequest, len(r.requests))
+	for i, req := range r.requests {
+		res[i] = *req
+	}
+	return res
+}
+
+// ExpectRequest creates an expectation of a request with the method and path,
+// e.g., "/cart". If the path has a query string, the query must also match.
+// Conditions can be added, e.g., [ExpectedRequest.WithForm].
+//
+// The expectation is verified when the test completes, or when the request is
+// inspected, e.g., with [ExpectedRequest.Status].
+func (r *RequestRecorder) ExpectRequest(method, path string) *ExpectedRequest {
+	e := &ExpectedRequest{recorder: r, method: method, path: path}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expectations = append(r.expectations, e)
+	return e
+}
+
+func (r *RequestRecorder) verify() {
+	r.t.Helper()
+	r.mu.Lock()
+	expectations := slices.Clone(r.expectations)
+	r.mu.Unlock()
+	for _, e := range expectations {
+		if _, ok := e.find(); !ok && !e.reported {
+			r.t.Errorf("%s", e.failure())
+		}
+	}
+}
+
+// ExpectedRequest is an expectation of a request to a [RequestRecorder].
+type ExpectedRequest struct {
+	recorder *RequestRecorder
+	method   string
+	path     string
+	form     url.Values
+	header   http.Header
+	// reported is true when a failure has already been reported.
+	reported bool
+}
+
+// WithForm adds the condition that the request has the form value, either in
+// the body, or in the query string.
+func (e *ExpectedRequest) WithForm(key, value string) *ExpectedRequest {
+	if e.form == nil {
+		e.form = make(url.Values)
+	}
+	e.form.Add(key, value)
+	return e
+}
+
+// WithHeader adds the condition that the request has the header value.
+func (e *ExpectedRequest) WithHeader(key, value string) *ExpectedRequest {
+	if e.header == nil {
+		e.header = make(http.Header)
+	}
+	e.header.Add(key, value)
+	return e
+}
+
+// Request returns the last request matching the expectation. A fatal error
+// is generated if no request matches.
+func (e *ExpectedRequest) Request() RecordedRequest {
+	e.recorder.t.Helper()
+	req, ok := e.find()
+	if !ok {
+		e.reported = true
+		e.recorder.t.Fatalf("%s", e.failure())
+	}
+	return req
+}
+
+// Status returns the response status of the last request matching the
+// expectation. The status is 0 if the handler hasn't yet written the
+// response, e.g., an HTMX request that is still in flight. A fatal error is
+// generated if no request matches.
+func (e *ExpectedRequest) Status() int {
+	e.recorder.t.Helper()
+	return e.Request().Status
+}
+
+func (e *ExpectedRequest) find() (RecordedRequest, bool) {
+	requests := e.recorder.Requests()
+	for _, r := range slices.Backward(requests) {
+		if e.isMatch(r) {
+			return r, true
+		}
+	}
+	return RecordedRequest{}, false
+}
+
+func (e *ExpectedRequest) isMatch(r RecordedRequest) bool {
+	if !strings.EqualFold(r.Method, e.method) {
+		return false
+	}
+	if r.URL.Path != e.path && r.URL.RequestURI() != e.path {
+		return false
+	}
+	for key, values := range e.form {
+		for _, v := range values {
+			if !slices.Contains(r.Form[key], v) {
+				return false
+			}
+		}
+	}
+	for key, values := range e.header {
+		for _, v := range values {
+			if !slices.Contains(r.Header.Values(key), v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (e *ExpectedRequest) String() string {
+	res := fmt.Sprintf("%s %s", e.method, e.path)
+	if len(e.form) > 0 {
+		res += " with form " + e.form.Encode()
+	}
+	for key, values := range e.header {
+		res += fmt.Sprintf(" with header %s: %s", key, strings.Join(values, ", "))
+	}
+	return res
+}
+
+func (e *ExpectedRequest) failure() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Expected request: %s\nRequests:", e)
+	requests := e.recorder.Requests()
+	if len(requests) == 0 {
+		b.WriteString(" none")
+	}
+	for _, r := range requests {
+		fmt.Fprintf(&b, "\n\t%s", r)
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package shaman_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/internal/testtb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cleanupTB records errors, and defers cleanup functions until cleanup is
+// called.
+type cleanupTB struct {
+	testtb.RecordingTB
+	cleanups []func()
+}
+
+func (t *cleanupTB) Cleanup(f func()) { t.cleanups = append(t.cleanups, f) }
+
+func (t *cleanupTB) cleanup() {
+	for _, f := range t.cleanups {
+		f()
+	}
+}
+
+func cartServer() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /products/42", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<main><h1>Shoes</h1>
+			<form method="post" action="/cart">
+				<label>Quantity <input type="text" name="qty" value="1" /></label>
+				<button>Add to cart</button>
+			</form>
+		</main>`)
+	})
+	mux.HandleFunc("POST /cart", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/cart", http.StatusSeeOther)
+	})
+	mux.HandleFunc("GET /cart", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<main><h1>Cart</h1></main>")
+	})
+	return mux
+}
+
+func TestRequestRecorder(t *testing.T) {
+	t.Parallel()
+	rec := NewRequestRecorder(t, cartServer())
+	scope := OpenHandler(t, rec, "/products/42", WithHeader("X-Session", "jane"))
+	scope.Textbox(ByName("Quantity")).Write("2")
+	scope.WaitForNavigation(func() {
+		scope.Get(ByRole(ariarole.Button), ByName("Add to cart")).Click()
+	})
+
+	rec.ExpectRequest("GET", "/products/42").WithHeader("X-Session", "jane")
+	assert.Equal(t, http.StatusSeeOther, rec.ExpectRequest("POST", "/cart").WithForm("qty", "2").Status())
+	assert.Equal(t, http.StatusOK, rec.ExpectRequest("GET", "/cart").Status())
+
+	requests := rec.Requests()
+	assert.Len(t, requests, 3)
+	assert.Equal(t, "POST /cart qty=2 (303)", requests[1].String())
+	assert.Equal(t, "qty=2", string(requests[1].Body))
+}
+
+func TestRequestRecorderUnmetExpectation(t *testing.T) {
+	t.Parallel()
+	tb := &cleanupTB{RecordingTB: testtb.RecordingTB{TB: t}}
+	rec := NewRequestRecorder(tb, cartServer())
+	OpenHandler(t, rec, "/products/42")
+	rec.ExpectRequest("GET", "/products/42")
+	rec.ExpectRequest("POST", "/cart").WithForm("qty", "2")
+
+	tb.cleanup()
+	assert.Equal(t, []string{
+		"Expected request: POST /cart with form qty=2\nRequests:\n\tGET /products/42 (200)",
+	}, tb.Errors)
+}
+
+func TestRequestRecorderResponseController(t *testing.T) {
+	t.Parallel()
+	var flushErr error
+	rec := NewRequestRecorder(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "data: 1\n\n")
+		flushErr = http.NewResponseController(w).Flush()
+	}))
+	w := httptest.NewRecorder()
+	rec.ServeHTTP(w, httptest.NewRequest("GET", "/events", nil))
+
+	assert.NoError(t, flushErr)
+	assert.True(t, w.Flushed)
+	rec.ExpectRequest("GET", "/events")
+}
@@ -0,0 +1,6 @@
+// Package require provides the same assertions as the assert package, but
+// stops the test on failure, like testify's require package.
+//
+//	require.Exists(t, scope, ByRole(ariarole.Dialog), ByName("Confirm"))
+//	dialog := scope.Dialog(ByName("Confirm"))
+package require
@@ -0,0 +1,116 @@
+package require
+
+import (
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/ariastate"
+	"github.com/gost-dom/shaman/assert"
+
+	"github.com/gost-dom/browser/dom"
+)
+
+// TestingT is the interface of the test, compatible with [testing.TB], and
+// testify's require.TestingT.
+type TestingT interface {
+	assert.TestingT
+	FailNow()
+}
+
+type tHelper interface{ Helper() }
+
+// HasRole requires that the element has the ARIA role.
+//
+// See also: [assert.HasRole]
+func HasRole(t TestingT, e dom.Element, role ariarole.Role, msgAndArgs ...any) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HasRole(t, e, role, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HasName requires that the element has the accessibility name.
+//
+// See also: [assert.HasName]
+func HasName(t TestingT, e dom.Element, name string, msgAndArgs ...any) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HasName(t, e, name, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HasDescription requires that the element has the accessibility description.
+//
+// See also: [assert.HasDescription]
+func HasDescription(t TestingT, e dom.Element, desc string, msgAndArgs ...any) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HasDescription(t, e, desc, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// HasState requires that the element has the ARIA state.
+//
+// See also: [assert.HasState]
+func HasState(t TestingT, e dom.Element, s ariastate.State, msgAndArgs ...any) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.HasState(t, e, s, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// NotHasState requires that the element doesn't have the ARIA state.
+//
+// See also: [assert.NotHasState]
+func NotHasState(t TestingT, e dom.Element, s ariastate.State, msgAndArgs ...any) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.NotHasState(t, e, s, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Exists requires that the scope contains an element matching the predicates.
+//
+// See also: [assert.Exists]
+func Exists(t TestingT, scope shaman.Scope, preds ...shaman.ElementPredicate) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Exists(t, scope, preds...) {
+		t.FailNow()
+	}
+}
+
+// NotExists requires that the scope doesn't contain an element matching the
+// predicates.
+//
+// See also: [assert.NotExists]
+func NotExists(t TestingT, scope shaman.Scope, preds ...shaman.ElementPredicate) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.NotExists(t, scope, preds...) {
+		t.FailNow()
+	}
+}
+
+// Count requires that the scope contains n elements matching the predicates.
+//
+// See also: [assert.Count]
+func Count(t TestingT, scope shaman.Scope, n int, preds ...shaman.ElementPredicate) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Count(t, scope, n, preds...) {
+		t.FailNow()
+	}
+}
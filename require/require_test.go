@@ -0,0 +1,31 @@
+package require_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/internal/testtb"
+	. "github.com/gost-dom/shaman/require"
+
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequire(t *testing.T) {
+	t.Parallel()
+	win, err := html.NewWindowReader(strings.NewReader(`<body><button>Save</button></body>`))
+	assert.NoError(t, err)
+	scope := shaman.NewScope(t, win.Document())
+
+	mt := &testtb.RecordingTB{TB: t}
+	Exists(mt, scope, shaman.ByRole(ariarole.Button))
+	HasName(mt, scope.Get(shaman.ByRole(ariarole.Button)), "Save")
+	assert.False(t, mt.Stopped)
+	assert.Empty(t, mt.Errors)
+
+	Exists(mt, scope, shaman.ByRole(ariarole.Alert))
+	assert.True(t, mt.Stopped)
+	assert.Len(t, mt.Errors, 1)
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"iter"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/node"
 
 	"github.com/gost-dom/browser/dom"
 	"github.com/gost-dom/browser/html"
@@ -59,6 +61,10 @@ func (o predicates) String() string {
 	return strings.Join(names, ", ")
 }
 
+// DescribePredicates describes predicates in messages, e.g., "By role:
+// button, By accessibility name: Save".
+func DescribePredicates(preds ...ElementPredicate) string { return predicates(preds).String() }
+
 // Scope represents a subset of a page, and can be used to find elements withing
 // that scope.
 type Scope struct {
@@ -75,12 +81,19 @@ type containerer interface {
 	container() dom.ElementContainer
 }
 
-type windowContainerer struct{ win html.Window }
+type windowContainerer struct {
+	win       html.Window
+	audit     *continuousAudit
+	responses *responseLog
+}
 
 func (c windowContainerer) container() dom.ElementContainer {
 	if c.win == nil {
 		return nil
 	}
+	if c.audit != nil {
+		c.audit.checkpoint()
+	}
 	return c.win.Document()
 }
 
@@ -90,8 +103,15 @@ func (s simpleContainer) container() dom.ElementContainer { return s.c }
 
 // WindowScope create a new [Scope] that is bound to an [html.Window]. This
 // scope will always reflect the current page displayed in the window.
-func WindowScope(t testing.TB, win html.Window) Scope {
-	return Scope{t, windowContainerer{win}}
+func WindowScope(t testing.TB, win html.Window, opts ...WindowScopeOption) Scope {
+	c := windowContainerer{win: win}
+	for _, o := range opts {
+		o(&c)
+	}
+	if c.audit != nil {
+		c.audit.start(t, win)
+	}
+	return Scope{t, c}
 }
 
 // NewScope create a new [Scope] that is bound to a single [dom.Element].
@@ -101,51 +121,35 @@ func NewScope(t testing.TB, c dom.ElementContainer) Scope {
 	return Scope{t: t, root: simpleContainer{c}}
 }
 
+// nodeScope returns the [NodeScope] finding the elements of the scope through
+// the Gost-DOM adapter of package [node].
+func (h Scope) nodeScope() NodeScope {
+	var root node.Node
+	if c := h.container(); c != nil {
+		root = node.FromDOM(c)
+	}
+	return NewNodeScope(h.t, root)
+}
+
 // All returns an iterator over all elements in scope. If the scope is an
 // element, the element itself will be included.
 func (h Scope) All() iter.Seq[dom.Element] {
 	return func(yield func(dom.Element) bool) {
-		container := h.container()
-		if container == nil {
-			return
-		}
-		if self, ok := container.(dom.Element); ok {
-			if !yield(self) {
+		for e := range h.nodeScope().All() {
+			if !yield(domElement(e)) {
 				return
 			}
 		}
-		for _, child := range container.Children().All() {
-			func() {
-				next, stop := iter.Pull(NewScope(h.t, child).All())
-				defer stop()
-				for {
-					v, ok := next()
-					if !ok || !yield(v) {
-						return
-
-					}
-				}
-			}()
-		}
 	}
 }
 
 // FindAll returns a sequence of all elements that match the specified options.
 func (h Scope) FindAll(options ...ElementPredicate) iter.Seq[dom.Element] {
-	opt := predicates(options)
 	return func(yield func(dom.Element) bool) {
-		next, done := iter.Pull(h.All())
-		defer done()
-		for {
-			e, ok := next()
-			if !ok {
+		for e := range h.nodeScope().FindAll(domPredicate(options)) {
+			if !yield(domElement(e)) {
 				return
 			}
-			if opt.IsMatch(e) {
-				if !yield(e) {
-					return
-				}
-			}
 		}
 	}
 }
@@ -158,20 +162,38 @@ func (h Scope) FindAll(options ...ElementPredicate) iter.Seq[dom.Element] {
 // Note: This must run in the same goroutine as the test case.
 func (h Scope) Find(opts ...ElementPredicate) html.HTMLElement {
 	h.t.Helper()
-	next, stop := iter.Pull(h.FindAll(opts...))
-	defer stop()
-	if v, ok := next(); ok {
-		if v2, ok := next(); ok {
-			h.t.Fatalf(
-				"At least two elements match options: %s\n1st match: %s\n2nd match: %s",
-				predicates(opts),
-				v.OuterHTML(),
-				v2.OuterHTML(),
-			)
+	v := domElement(h.nodeScope().Find(domPredicate(opts)))
+	if v == nil {
+		return nil
+	}
+	h.checkInert(v)
+	if input, ok := v.(html.HTMLInputElement); ok {
+		switch input.Type() {
+		case "checkbox", "radio":
+			// Initialise the checkedness before the element is clicked.
+			initChecked(input)
 		}
-		return v.(html.HTMLElement)
 	}
-	return nil
+	return v.(html.HTMLElement)
+}
+
+// checkInert generates an error if e is outside an open modal dialog of its
+// document. While a modal dialog is open, the rest of the page is inert, and
+// the user cannot interact with it.
+func (h Scope) checkInert(e dom.Element) {
+	h.t.Helper()
+	doc := e.OwnerDocument()
+	if doc == nil || !e.IsConnected() {
+		return
+	}
+	if dialog := documentModalDialog(h.t, doc); dialog != nil && dialog != e &&
+		!dialog.Contains(e) {
+		h.t.Errorf(
+			"Element is inert, as it is outside an open modal dialog\nElement: %s\nDialog: %s",
+			e.OuterHTML(),
+			dialog.OuterHTML(),
+		)
+	}
 }
 
 // Get returns the element that matches the options. Exactly one element is
@@ -224,17 +246,29 @@ type TextboxRole struct {
 	html.HTMLElement
 }
 
+func (tb TextboxRole) unwrap() html.HTMLElement { return tb.HTMLElement }
+
 func (tb TextboxRole) Value() string {
+	if tb.TagName() == "TEXTAREA" {
+		return tb.TextContent()
+	}
 	v, _ := tb.HTMLElement.GetAttribute("value")
 	return v
 }
 
 // Write is intended to simulate the user typing in. Currently it merely sets
-// the value content attribute, making it only applicable to input elements, not
-// custom implementations of the textbox aria role.
-func (tb TextboxRole) Write(input string) { tb.SetAttribute("value", input) }
+// the value content attribute, or the text content of a <textarea>, making it
+// only applicable to native elements, not custom implementations of the
+// textbox aria role.
+func (tb TextboxRole) Write(input string) {
+	if tb.TagName() == "TEXTAREA" {
+		tb.SetTextContent(input)
+		return
+	}
+	tb.SetAttribute("value", input)
+}
 
-func (tb TextboxRole) Clear() { tb.SetAttribute("value", "") }
+func (tb TextboxRole) Clear() { tb.Write("") }
 
 func (tb TextboxRole) ARIADescription() string {
 	return GetDescription(tb)
@@ -244,6 +278,8 @@ type CheckboxRole struct {
 	html.HTMLElement
 }
 
+func (cb CheckboxRole) unwrap() html.HTMLElement { return cb.HTMLElement }
+
 func (cb CheckboxRole) Check()   { cb.setChecked(true) }
 func (cb CheckboxRole) Uncheck() { cb.setChecked(false) }
 
@@ -255,5 +291,38 @@ func (cb CheckboxRole) setChecked(val bool) {
 		// call Click() on the element if it has the wrong state.
 		panic("CheckboxRole.Check/Uncheck: only input elements are supported")
 	}
+	setChecked(input, val)
+}
+
+// initialisedChecked holds the checkboxes and radio buttons whose checkedness
+// was initialised from the checked content attribute. The browser doesn't
+// initialise the checkedness when parsing HTML, so it is initialised when the
+// element is first found or inspected. From then on, the checkedness, changed
+// by clicks or scripts, is authoritative, like the dirty checkedness flag of a
+// browser.
+var (
+	initialisedChecked   = make(map[html.HTMLInputElement]struct{})
+	initialisedCheckedMu sync.Mutex
+)
+
+// initChecked initialises the checkedness of a checkbox or radio button from
+// the checked content attribute, unless already initialised.
+func initChecked(input html.HTMLInputElement) {
+	initialisedCheckedMu.Lock()
+	defer initialisedCheckedMu.Unlock()
+	if _, ok := initialisedChecked[input]; !ok {
+		initialisedChecked[input] = struct{}{}
+		input.SetChecked(input.HasAttribute("checked"))
+	}
+}
+
+// inputChecked returns the checkedness of a checkbox or radio button.
+func inputChecked(input html.HTMLInputElement) bool {
+	initChecked(input)
+	return input.Checked()
+}
+
+func setChecked(input html.HTMLInputElement, val bool) {
+	initChecked(input)
 	input.SetChecked(val)
 }
@@ -0,0 +1,18 @@
+// Package screenreader simulates the virtual cursor of a screen reader, making
+// it possible to test the experience of screen reader users.
+//
+// A screen reader presents a page as a linear sequence of stops in reading
+// order. Each stop is spoken, e.g., "heading level 2, Orders", or "button,
+// Delete, disabled". The user moves the virtual cursor one stop at a time, or
+// jumps to the next element of a kind, e.g., the next heading, or the next
+// landmark.
+//
+//	reader := screenreader.New(t, win.Document())
+//	assert.Equal(t, "main", reader.NextLandmark().Speech)
+//	assert.Equal(t, "heading level 1, Orders", reader.NextHeading().Speech)
+//	assert.Equal(t, "textbox, Search", reader.NextFormField().Speech)
+//
+// The speech follows a simple format, the role, the accessibility name, the
+// value, and the states; separated by commas. It is inspired by, but doesn't
+// reproduce, the output of any particular screen reader.
+package screenreader
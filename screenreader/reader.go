@@ -0,0 +1,396 @@
+package screenreader
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/ariastate"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+// Stop is a position of the virtual cursor; an element, or a block of text.
+type Stop struct {
+	// Node is the element, or the node containing a block of text.
+	Node dom.Node
+	// Role is the role of the element; empty for text.
+	Role ariarole.Role
+	// Name is the accessibility name of the element; empty for text.
+	Name string
+	// Speech is what the screen reader speaks when the cursor moves to the
+	// stop.
+	Speech string
+}
+
+func (s Stop) String() string { return s.Speech }
+
+// Reader simulates the virtual cursor of a screen reader.
+//
+// The stops are calculated from the current state of the DOM on every move,
+// so the reader reflects changes to the page. If the element at the cursor is
+// removed from the DOM, the cursor restarts from the top.
+type Reader struct {
+	t       testing.TB
+	root    dom.ElementContainer
+	current dom.Node
+}
+
+// New creates a Reader for the content of root, e.g., a document. The cursor
+// is placed before the first stop.
+func New(t testing.TB, root dom.ElementContainer) *Reader {
+	return &Reader{t: t, root: root}
+}
+
+// Stops returns all stops in reading order.
+func (r *Reader) Stops() []Stop {
+	var res []Stop
+	for _, c := range r.root.ChildNodes().All() {
+		res = appendStops(res, c)
+	}
+	if e, ok := r.root.(dom.Element); ok {
+		if s, ok := elementStop(e); ok {
+			res = append([]Stop{s}, res...)
+		}
+	}
+	return res
+}
+
+// ReadAll returns the speech of all stops in reading order.
+func (r *Reader) ReadAll() []string {
+	stops := r.Stops()
+	res := make([]string, len(stops))
+	for i, s := range stops {
+		res[i] = s.Speech
+	}
+	return res
+}
+
+// Current returns the stop at the cursor. Returns a zero value if the cursor
+// hasn't moved yet.
+func (r *Reader) Current() Stop {
+	stops := r.Stops()
+	if i := r.index(stops); i >= 0 {
+		return stops[i]
+	}
+	return Stop{}
+}
+
+// Reset moves the cursor to the top of the content.
+func (r *Reader) Reset() { r.current = nil }
+
+// Next moves the cursor to the next stop. A fatal error is generated at the end
+// of the content.
+func (r *Reader) Next() Stop {
+	r.t.Helper()
+	return r.next("stop", func(Stop) bool { return true })
+}
+
+// Previous moves the cursor to the previous stop. A fatal error is generated at
+// the top of the content.
+func (r *Reader) Previous() Stop {
+	r.t.Helper()
+	stops := r.Stops()
+	i := r.index(stops)
+	if i <= 0 {
+		r.t.Fatalf("No previous stop")
+		return Stop{}
+	}
+	r.current = stops[i-1].Node
+	return stops[i-1]
+}
+
+// NextHeading moves the cursor to the next heading.
+func (r *Reader) NextHeading() Stop {
+	r.t.Helper()
+	return r.next("heading", hasRole(ariarole.Heading))
+}
+
+// NextLandmark moves the cursor to the next landmark, e.g., "main", or
+// "navigation".
+func (r *Reader) NextLandmark() Stop {
+	r.t.Helper()
+	return r.next("landmark", func(s Stop) bool { return landmarkRoles[s.Role] })
+}
+
+// NextFormField moves the cursor to the next form field, including buttons.
+func (r *Reader) NextFormField() Stop {
+	r.t.Helper()
+	return r.next("form field", func(s Stop) bool {
+		return s.Role.FormField() || s.Role == ariarole.Button
+	})
+}
+
+// NextLink moves the cursor to the next link.
+func (r *Reader) NextLink() Stop {
+	r.t.Helper()
+	return r.next("link", hasRole(ariarole.Link))
+}
+
+func hasRole(role ariarole.Role) func(Stop) bool {
+	return func(s Stop) bool { return s.Role == role }
+}
+
+func (r *Reader) next(kind string, match func(Stop) bool) Stop {
+	r.t.Helper()
+	stops := r.Stops()
+	for _, s := range stops[r.index(stops)+1:] {
+		if match(s) {
+			r.current = s.Node
+			return s
+		}
+	}
+	r.t.Fatalf("No next %s", kind)
+	return Stop{}
+}
+
+// index returns the index of the current stop, or -1 if the cursor is at the
+// top.
+func (r *Reader) index(stops []Stop) int {
+	if r.current == nil {
+		return -1
+	}
+	return slices.IndexFunc(stops, func(s Stop) bool { return s.Node == r.current })
+}
+
+var landmarkRoles = map[ariarole.Role]bool{
+	ariarole.Banner:        true,
+	ariarole.Main:          true,
+	ariarole.Navigation:    true,
+	ariarole.Complementary: true,
+	ariarole.Contentinfo:   true,
+	ariarole.Region:        true,
+	ariarole.Search:        true,
+	ariarole.Form:          true,
+}
+
+// leafRoles are the roles of elements spoken as a whole, where the content is
+// part of the name or value, so the cursor doesn't move into the element.
+var leafRoles = map[ariarole.Role]bool{
+	ariarole.Button:           true,
+	ariarole.Link:             true,
+	ariarole.Heading:          true,
+	ariarole.Img:              true,
+	ariarole.Textbox:          true,
+	ariarole.Searchbox:        true,
+	ariarole.PasswordText:     true,
+	ariarole.Checkbox:         true,
+	ariarole.Radio:            true,
+	ariarole.Combobox:         true,
+	ariarole.Listbox:          true,
+	ariarole.Slider:           true,
+	ariarole.Spinbutton:       true,
+	ariarole.Progressbar:      true,
+	ariarole.Meter:            true,
+	ariarole.Option:           true,
+	ariarole.Tab:              true,
+	ariarole.Menuitem:         true,
+	ariarole.Menuitemcheckbox: true,
+	ariarole.Menuitemradio:    true,
+}
+
+// containerRoles are the roles of elements announced when the cursor enters
+// them, before their content.
+var containerRoles = map[ariarole.Role]bool{
+	ariarole.List:        true,
+	ariarole.Table:       true,
+	ariarole.Grid:        true,
+	ariarole.Treegrid:    true,
+	ariarole.Tree:        true,
+	ariarole.Treeitem:    true,
+	ariarole.Tablist:     true,
+	ariarole.Tabpanel:    true,
+	ariarole.Menu:        true,
+	ariarole.Menubar:     true,
+	ariarole.Dialog:      true,
+	ariarole.Alertdialog: true,
+	ariarole.Radiogroup:  true,
+	ariarole.Group:       true,
+	ariarole.Alert:       true,
+	ariarole.Article:     true,
+}
+
+func appendStops(stops []Stop, n dom.Node) []Stop {
+	e, ok := n.(dom.Element)
+	if !ok {
+		if text := collapse(n.TextContent()); text != "" && n.NodeType() == dom.NodeTypeText {
+			stops = append(stops, Stop{Node: n, Speech: text})
+		}
+		return stops
+	}
+	if shaman.ElementHidden(e) {
+		return stops
+	}
+	if s, ok := elementStop(e); ok {
+		stops = append(stops, s)
+		if leafRoles[s.Role] {
+			return stops
+		}
+	} else if !hasStops(e) {
+		// A block of text, e.g., a paragraph, is read as one stop.
+		if text := collapse(visibleText(e)); text != "" {
+			stops = append(stops, Stop{Node: e, Speech: text})
+		}
+		return stops
+	}
+	for _, c := range e.ChildNodes().All() {
+		if _, ok := c.(dom.Element); !ok && ariarole.GetElementRole(e) == ariarole.Treeitem {
+			// The text of a tree item is part of its name
+			continue
+		}
+		stops = appendStops(stops, c)
+	}
+	return stops
+}
+
+// visibleText returns the text content of e, excluding hidden elements.
+func visibleText(e dom.Element) string {
+	var b strings.Builder
+	for _, c := range e.ChildNodes().All() {
+		if c, ok := c.(dom.Element); ok {
+			if !shaman.ElementHidden(c) {
+				b.WriteString(visibleText(c))
+			}
+			continue
+		}
+		if c.NodeType() == dom.NodeTypeText {
+			b.WriteString(c.TextContent())
+		}
+	}
+	return b.String()
+}
+
+// hasStops returns whether e contains elements that are stops of their own.
+func hasStops(e dom.Element) bool {
+	for _, c := range e.Children().All() {
+		if shaman.ElementHidden(c) {
+			continue
+		}
+		if _, ok := elementStop(c); ok || hasStops(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// elementStop returns the stop for an element, if the element is a stop of its
+// own.
+func elementStop(e dom.Element) (Stop, bool) {
+	role := ariarole.GetElementRole(e)
+	if role == ariarole.Img {
+		if alt, ok := e.GetAttribute("alt"); ok && alt == "" {
+			// An image with an empty alt text is decorative
+			return Stop{}, false
+		}
+	}
+	if !leafRoles[role] && !containerRoles[role] && !landmarkRoles[role] {
+		return Stop{}, false
+	}
+	name := collapse(shaman.ElementName(e))
+	if role == ariarole.Form && name == "" {
+		// A form is only a landmark when it has a name
+		return Stop{}, false
+	}
+	parts := []string{roleText(e, role), name}
+	parts = append(parts, value(e, role))
+	parts = append(parts, states(e, role)...)
+	return Stop{Node: e, Role: role, Name: name, Speech: join(parts)}, true
+}
+
+func roleText(e dom.Element, role ariarole.Role) string {
+	switch role {
+	case ariarole.Heading:
+		return fmt.Sprintf("heading level %d", ariarole.HeadingLevel(e))
+	case ariarole.PasswordText:
+		return "password"
+	case ariarole.List:
+		var items int
+		for _, c := range e.Children().All() {
+			if ariarole.GetElementRole(c) == ariarole.Listitem {
+				items++
+			}
+		}
+		return fmt.Sprintf("list, %d items", items)
+	}
+	return string(role)
+}
+
+// value returns the value spoken for form fields and range widgets.
+func value(e dom.Element, role ariarole.Role) string {
+	switch role {
+	case ariarole.Textbox, ariarole.Searchbox, ariarole.Combobox, ariarole.Spinbutton,
+		ariarole.Slider, ariarole.Progressbar, ariarole.Meter:
+	case ariarole.PasswordText:
+		// Screen readers don't speak passwords
+		return ""
+	default:
+		return ""
+	}
+	if text, ok := e.GetAttribute("aria-valuetext"); ok {
+		return text
+	}
+	if v, ok := e.GetAttribute("aria-valuenow"); ok {
+		return v
+	}
+	switch e.TagName() {
+	case "TEXTAREA":
+		return collapse(e.TextContent())
+	case "SELECT":
+		if selected := shaman.ElementSelectedOptions(e); len(selected) > 0 {
+			return collapse(shaman.ElementName(selected[0]))
+		}
+		return ""
+	}
+	if input, ok := e.(html.HTMLInputElement); ok {
+		return input.Value()
+	}
+	v, _ := e.GetAttribute("value")
+	return v
+}
+
+func states(e dom.Element, role ariarole.Role) []string {
+	var res []string
+	attr := func(name string) string { v, _ := e.GetAttribute(name); return v }
+	switch role {
+	case ariarole.Checkbox, ariarole.Radio, ariarole.Menuitemcheckbox, ariarole.Menuitemradio:
+		if shaman.ElementChecked(e) {
+			res = append(res, "checked")
+		} else {
+			res = append(res, "not checked")
+		}
+	}
+	switch {
+	case shaman.ElementHasState(e, ariastate.Expanded):
+		res = append(res, "expanded")
+	case attr("aria-expanded") == "false":
+		res = append(res, "collapsed")
+	}
+	if shaman.ElementHasState(e, ariastate.Selected) {
+		res = append(res, "selected")
+	}
+	if shaman.ElementHasState(e, ariastate.Pressed) {
+		res = append(res, "pressed")
+	}
+	if e.HasAttribute("required") || attr("aria-required") == "true" {
+		res = append(res, "required")
+	}
+	// Native constraints aren't exposed until the user submits the form, so
+	// only aria-invalid is considered.
+	if v := attr("aria-invalid"); v != "" && v != "false" {
+		res = append(res, "invalid")
+	}
+	if shaman.ElementDisabled(e) {
+		res = append(res, "disabled")
+	}
+	return res
+}
+
+func join(parts []string) string {
+	return strings.Join(slices.DeleteFunc(parts, func(s string) bool { return s == "" }), ", ")
+}
+
+func collapse(s string) string { return strings.Join(strings.Fields(s), " ") }
@@ -0,0 +1,116 @@
+package screenreader_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/screenreader"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadHTML(t *testing.T, h string) dom.Document {
+	t.Helper()
+	win, err := html.NewWindowReader(strings.NewReader(h))
+	if err != nil {
+		t.Fatalf("Error parsing HTML document")
+	}
+	return win.Document()
+}
+
+const page = `
+	<header>
+		<img src="logo.png" alt="Acme" />
+		<nav aria-label="Primary">
+			<ul>
+				<li><a href="/">Home</a></li>
+				<li><a href="/orders">Orders</a></li>
+			</ul>
+		</nav>
+	</header>
+	<main>
+		<h1>Orders</h1>
+		<p>You have <strong>2</strong> open orders.</p>
+		<img src="divider.png" alt="" />
+		<section aria-label="Filter">
+			<label>Search <input type="text" value="shoes" /></label>
+			<label><input type="checkbox" checked /> Include closed</label>
+			<label>Status
+				<select><option>Open</option><option selected>Shipped</option></select>
+			</label>
+		</section>
+		<h2>Order 1</h2>
+		<span hidden>Secret</span>
+		<button disabled>Delete</button>
+		<button aria-expanded="false">More</button>
+		<input aria-label="Quantity" type="number" value="3" required aria-invalid="true" />
+	</main>
+	<footer>Copyright</footer>`
+
+func TestReadAll(t *testing.T) {
+	t.Parallel()
+	reader := screenreader.New(t, loadHTML(t, page))
+	assert.Equal(t, []string{
+		"banner",
+		"img, Acme",
+		"navigation, Primary",
+		"list, 2 items",
+		"link, Home",
+		"link, Orders",
+		"main",
+		"heading level 1, Orders",
+		"You have 2 open orders.",
+		"region, Filter",
+		"Search",
+		"textbox, Search, shoes",
+		"checkbox, Include closed, checked",
+		"Include closed",
+		"Status",
+		"combobox, Status, Shipped",
+		"heading level 2, Order 1",
+		"button, Delete, disabled",
+		"button, More, collapsed",
+		"spinbutton, Quantity, 3, required, invalid",
+		"contentinfo",
+		"Copyright",
+	}, reader.ReadAll())
+}
+
+func TestNavigation(t *testing.T) {
+	t.Parallel()
+	reader := screenreader.New(t, loadHTML(t, page))
+
+	assert.Equal(t, "banner", reader.Next().Speech)
+	assert.Equal(t, "img, Acme", reader.Next().Speech)
+	assert.Equal(t, "heading level 1, Orders", reader.NextHeading().Speech)
+	assert.Equal(t, "You have 2 open orders.", reader.Next().Speech)
+	assert.Equal(t, "heading level 1, Orders", reader.Previous().Speech)
+	assert.Equal(t, "region, Filter", reader.NextLandmark().Speech)
+	assert.Equal(t, "textbox, Search, shoes", reader.NextFormField().Speech)
+	assert.Equal(t, "checkbox, Include closed, checked", reader.NextFormField().Speech)
+	assert.Equal(t, "heading level 2, Order 1", reader.NextHeading().Speech)
+	assert.Equal(t, "contentinfo", reader.NextLandmark().Speech)
+	assert.Equal(t, "contentinfo", reader.Current().Speech)
+
+	reader.Reset()
+	assert.Equal(t, "link, Home", reader.NextLink().Speech)
+}
+
+func TestFormFieldStates(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<fieldset disabled>
+			<label><input type="checkbox" checked /> Include closed</label>
+			<label>Size
+				<select><option disabled>S</option><option>M</option></select>
+			</label>
+		</fieldset>`)
+	shaman.NewScope(t, doc).Get(shaman.ByName("Include closed")).Click()
+
+	reader := screenreader.New(t, doc)
+	assert.Equal(t, "checkbox, Include closed, not checked, disabled", reader.NextFormField().Speech)
+	assert.Equal(t, "combobox, Size, M, disabled", reader.NextFormField().Speech)
+}
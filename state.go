@@ -0,0 +1,183 @@
+package shaman
+
+import (
+	"strings"
+
+	"github.com/gost-dom/shaman/ariastate"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+// ElementChecked returns whether a checkbox, radio button, or an element with
+// aria-checked, e.g., a switch, is checked. A mixed checkbox is not checked.
+func ElementChecked(e dom.Element) bool {
+	if input, ok := unwrapElement(e).(html.HTMLInputElement); ok {
+		switch input.Type() {
+		case "checkbox", "radio":
+			return inputChecked(input)
+		}
+	}
+	v, _ := e.GetAttribute("aria-checked")
+	return v == "true"
+}
+
+// ElementDisabled returns whether an element is disabled; a native form control
+// by the disabled attribute, or a disabled <fieldset>; or any element by
+// aria-disabled on the element, or an ancestor.
+func ElementDisabled(e dom.Element) bool {
+	switch e.TagName() {
+	case "BUTTON", "INPUT", "SELECT", "TEXTAREA", "FIELDSET", "OPTGROUP":
+		if disabled(e) {
+			return true
+		}
+	case "OPTION":
+		if e.HasAttribute("disabled") {
+			return true
+		}
+		if p := e.ParentElement(); p != nil && p.TagName() == "OPTGROUP" && p.HasAttribute("disabled") {
+			return true
+		}
+	}
+	for ; e != nil; e = e.ParentElement() {
+		if v, _ := e.GetAttribute("aria-disabled"); v == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// ElementVisible returns whether an element is rendered. As the browser
+// doesn't apply stylesheets, only the hidden attribute, inline display and
+// visibility styles, and elements that are never rendered, e.g., <template>,
+// or a closed <dialog>, are considered.
+//
+// Unlike aria-hidden, which hides an element from assistive technologies, this
+// is visibility to sighted users.
+func ElementVisible(e dom.Element) bool {
+	for ; e != nil; e = e.ParentElement() {
+		switch e.TagName() {
+		case "HEAD", "SCRIPT", "STYLE", "TEMPLATE", "NOSCRIPT":
+			return false
+		case "DIALOG":
+			if !e.HasAttribute("open") {
+				return false
+			}
+		case "INPUT":
+			if t, _ := e.GetAttribute("type"); strings.EqualFold(t, "hidden") {
+				return false
+			}
+		}
+		if e.HasAttribute("hidden") || styleHidden(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// styleHidden returns whether the inline style hides the element.
+func styleHidden(e dom.Element) bool {
+	style, _ := e.GetAttribute("style")
+	for _, decl := range strings.Split(style, ";") {
+		prop, value, _ := strings.Cut(decl, ":")
+		prop = strings.TrimSpace(strings.ToLower(prop))
+		value = strings.TrimSpace(strings.ToLower(value))
+		value = strings.TrimSpace(strings.TrimSuffix(value, "!important"))
+		if (prop == "display" && value == "none") ||
+			(prop == "visibility" && value == "hidden") {
+			return true
+		}
+	}
+	return false
+}
+
+// ElementHidden returns whether an element is hidden from assistive
+// technologies; by aria-hidden, or the hidden attribute, on the element or an
+// ancestor, or as an element that is never rendered, e.g., <template>, or a
+// closed <dialog>.
+//
+// Unlike [ElementVisible], inline styles are not considered.
+func ElementHidden(e dom.Element) bool {
+	for ; e != nil; e = e.ParentElement() {
+		switch e.TagName() {
+		case "HEAD", "SCRIPT", "STYLE", "TEMPLATE", "NOSCRIPT":
+			return true
+		case "DIALOG":
+			if !e.HasAttribute("open") {
+				return true
+			}
+		case "INPUT":
+			if t, _ := e.GetAttribute("type"); strings.EqualFold(t, "hidden") {
+				return true
+			}
+		}
+		if v, _ := e.GetAttribute("aria-hidden"); v == "true" || e.HasAttribute("hidden") {
+			return true
+		}
+	}
+	return false
+}
+
+// ElementHasState returns whether an element has the [ARIA state]. States
+// inherent to native elements are considered, e.g., a disabled <button>, or a
+// <details> element that is open, is expanded.
+//
+// [ARIA state]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes#states_and_properties
+func ElementHasState(e dom.Element, s ariastate.State) bool {
+	e = unwrapElement(e)
+	attr := func(name string) string {
+		v, _ := e.GetAttribute(name)
+		return v
+	}
+	switch s {
+	case ariastate.Checked:
+		return ElementChecked(e)
+	case ariastate.Disabled:
+		return ElementDisabled(e)
+	case ariastate.Expanded:
+		if e.TagName() == "DETAILS" {
+			return e.HasAttribute("open")
+		}
+		if p := e.ParentElement(); e.TagName() == "SUMMARY" && p != nil && p.TagName() == "DETAILS" {
+			return p.HasAttribute("open")
+		}
+		return attr("aria-expanded") == "true"
+	case ariastate.Hidden:
+		return !ElementVisible(e) || ElementHidden(e)
+	case ariastate.Invalid:
+		return !ElementValidity(e).Valid()
+	case ariastate.Selected:
+		return isSelected(e)
+	case ariastate.Current:
+		v, ok := e.GetAttribute("aria-current")
+		return ok && v != "false"
+	}
+	return attr("aria-"+string(s)) == "true"
+}
+
+// ElementFocused returns whether an element is the active element of its
+// document.
+func ElementFocused(e dom.Element) bool {
+	doc := e.OwnerDocument()
+	if doc == nil {
+		return false
+	}
+	active := doc.ActiveElement()
+	return active != nil && active.ObjectId() == e.ObjectId()
+}
+
+// roleWrapper is implemented by the role types wrapping an element, e.g., a
+// [CheckboxRole].
+type roleWrapper interface{ unwrap() html.HTMLElement }
+
+// unwrapElement returns the element of the document for an element wrapped in
+// a role type, e.g., a [CheckboxRole], making type assertions possible.
+func unwrapElement(e dom.Element) dom.Element {
+	for {
+		w, ok := e.(roleWrapper)
+		if !ok {
+			return e
+		}
+		e = w.unwrap()
+	}
+}
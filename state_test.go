@@ -0,0 +1,96 @@
+package shaman_test
+
+import (
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/ariastate"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElementState(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `<body>
+		<label><input type="checkbox" checked /> Terms</label>
+		<div role="checkbox" aria-checked="mixed" aria-label="All"></div>
+		<div aria-disabled="true"><button>Save</button></div>
+		<select aria-label="Plan"><optgroup label="Paid" disabled><option>Pro</option></optgroup></select>
+		<div style="color: red; Display: none !important"><p>Hidden</p></div>
+		<p style="visibility:hidden">Invisible</p>
+		<p aria-hidden="true">Decorative</p>
+	</body>`)
+	scope := NewScope(t, doc)
+
+	terms := scope.Checkbox(ByName("Terms"))
+	assert.True(t, ElementChecked(terms), "Checkbox in role type")
+	terms.Uncheck()
+	assert.False(t, ElementChecked(terms), "Unchecked")
+	assert.False(t, ElementChecked(scope.Get(ByName("All"))), "Mixed is not checked")
+
+	assert.True(t, ElementDisabled(scope.Get(ByRole(ariarole.Button))), "aria-disabled ancestor")
+	assert.True(t, ElementDisabled(scope.Get(ByRole(ariarole.Option))), "Disabled optgroup")
+	assert.False(t, ElementDisabled(scope.Get(ByRole(ariarole.Combobox))))
+
+	visible := map[string]bool{}
+	hidden := map[string]bool{}
+	paragraphs, err := doc.QuerySelectorAll("p")
+	assert.NoError(t, err)
+	for _, p := range paragraphs.All() {
+		visible[p.TextContent()] = ElementVisible(p.(dom.Element))
+		hidden[p.TextContent()] = ElementHidden(p.(dom.Element))
+	}
+	assert.Equal(t, map[string]bool{
+		"Hidden":     false,
+		"Invisible":  false,
+		"Decorative": true,
+	}, visible)
+	assert.Equal(t, map[string]bool{
+		"Hidden":     false,
+		"Invisible":  false,
+		"Decorative": true,
+	}, hidden, "Hidden from assistive technologies")
+
+	detached := doc.CreateElement("input")
+	detached.SetAttribute("type", "checkbox")
+	detached.SetAttribute("checked", "")
+	assert.True(t, ElementChecked(CheckboxRole{detached.(html.HTMLElement)}),
+		"Detached checkbox in role type")
+
+	assert.False(t, ElementFocused(terms))
+	terms.Focus()
+	assert.True(t, ElementFocused(terms))
+}
+
+func TestElementHasState(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `<body>
+		<button aria-expanded="false">Menu</button>
+		<details open><summary>Details</summary>Content</details>
+		<a href="/" aria-current="page">Home</a>
+		<button aria-pressed="true">Bold</button>
+		<label>Email <input required /></label>
+		<div aria-hidden="true"><button>Decorative</button></div>
+		<select aria-label="Size"><option>S</option><option selected>M</option></select>
+	</body>`)
+	scope := NewScope(t, doc)
+
+	assert.False(t, ElementHasState(scope.Get(ByName("Menu")), ariastate.Expanded))
+	summary, err := doc.QuerySelector("summary")
+	assert.NoError(t, err)
+	assert.True(t, ElementHasState(summary, ariastate.Expanded), "Open <details>")
+	assert.True(t, ElementHasState(scope.Get(ByName("Home")), ariastate.Current))
+	assert.True(t, ElementHasState(scope.Get(ByName("Bold")), ariastate.Pressed))
+	assert.True(t, ElementHasState(scope.Get(ByName("Email")), ariastate.Invalid), "Required field is empty")
+	email := scope.Textbox(ByName("Email"))
+	assert.True(t, ElementHasState(email, ariastate.Invalid), "Textbox in role type")
+	email.Write("jd@example.com")
+	assert.False(t, ElementHasState(email, ariastate.Invalid), "Textbox in role type")
+	assert.True(t, ElementHasState(scope.Get(ByName("Decorative")), ariastate.Hidden))
+	assert.False(t, ElementHasState(scope.Get(ByName("Menu")), ariastate.Hidden))
+	assert.True(t, ElementHasState(scope.Get(ByName("M")), ariastate.Selected))
+	assert.False(t, ElementHasState(scope.Get(ByName("S")), ariastate.Selected))
+}
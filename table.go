@@ -0,0 +1,311 @@
+package shaman
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+type tablePredicate struct{}
+
+func (tablePredicate) IsMatch(e dom.Element) bool { return isTable(e) }
+
+func (tablePredicate) String() string { return "By role: table, or grid" }
+
+func isTable(e dom.Element) bool {
+	switch ariarole.GetElementRole(e) {
+	case ariarole.Table, ariarole.Grid:
+		return true
+	}
+	return false
+}
+
+// Table finds the element with the role "table" or "grid" that matches the
+// options. The name of a <table> is the text of its <caption>.
+func (s Scope) Table(opts ...ElementPredicate) TableRole {
+	s.t.Helper()
+	opts = append(opts, tablePredicate{})
+	return TableRole{s.Get(opts...), s.t}
+}
+
+// TableRole is a helper to read the contents of a table, identifying cells by
+// the column header.
+//
+// Column headers are read from the first row where all cells are column
+// headers, e.g., <th> elements in a <thead>. A cell belongs to the columns it
+// spans; or to the headers referenced by its headers attribute, if present.
+// The rowspan attribute is not supported.
+type TableRole struct {
+	html.HTMLElement
+	t testing.TB
+}
+
+func (tb TableRole) unwrap() html.HTMLElement { return tb.HTMLElement }
+
+// Headers returns the names of the column headers.
+func (tb TableRole) Headers() []string {
+	var res []string
+	headers := columnHeaders(tb.HTMLElement)
+	for i, h := range headers {
+		if i == 0 || headers[i-1] != h {
+			res = append(res, headerName(h))
+		}
+	}
+	return res
+}
+
+// Rows returns all rows in the table, except header rows.
+func (tb TableRole) Rows() []TableRowRole {
+	var res []TableRowRole
+	for _, r := range tableRows(tb.HTMLElement) {
+		if !isHeaderRow(r) {
+			res = append(res, TableRowRole{r, tb})
+		}
+	}
+	return res
+}
+
+// Row returns the single row matching the options, e.g., [ByCell]. A fatal
+// error is generated if zero, or more than one row match.
+func (tb TableRole) Row(opts ...ElementPredicate) TableRowRole {
+	tb.t.Helper()
+	var res []TableRowRole
+	for _, r := range tb.Rows() {
+		if predicates(opts).IsMatch(r) {
+			res = append(res, r)
+		}
+	}
+	switch len(res) {
+	case 0:
+		tb.t.Fatalf("No rows matching options: %s", predicates(opts))
+	case 1:
+		return res[0]
+	default:
+		tb.t.Fatalf(
+			"At least two rows match options: %s\n1st match: %s\n2nd match: %s",
+			predicates(opts), res[0].OuterHTML(), res[1].OuterHTML(),
+		)
+	}
+	return TableRowRole{}
+}
+
+// Cell returns the cell in the column, in the single row matching row. A fatal
+// error is generated if the row or the column doesn't exist.
+func (tb TableRole) Cell(row ElementPredicate, column string) html.HTMLElement {
+	tb.t.Helper()
+	return tb.Row(row).Cell(column)
+}
+
+// ColumnValues returns the text of the cells in the column, one for each row.
+func (tb TableRole) ColumnValues(column string) []string {
+	tb.t.Helper()
+	tb.assertColumn(column)
+	var res []string
+	for _, r := range tb.Rows() {
+		res = append(res, cellText(r.cellIn(column)))
+	}
+	return res
+}
+
+// ToRecords returns the contents of the table, one map for each row, mapping
+// column header names to cell text. This is helpful for asserting the contents
+// of a table in one go.
+func (tb TableRole) ToRecords() []map[string]string {
+	var res []map[string]string
+	for _, r := range tb.Rows() {
+		res = append(res, r.Record())
+	}
+	return res
+}
+
+func (tb TableRole) assertColumn(column string) {
+	tb.t.Helper()
+	if headers := tb.Headers(); !slices.Contains(headers, column) {
+		tb.t.Fatalf(
+			"No column named %q. Columns: %s",
+			column, strings.Join(headers, ", "),
+		)
+	}
+}
+
+// TableRowRole is a helper to read the cells of a row in a table.
+type TableRowRole struct {
+	html.HTMLElement
+	table TableRole
+}
+
+func (r TableRowRole) unwrap() html.HTMLElement { return r.HTMLElement }
+
+// Cells returns all cells in the row, including row headers.
+func (r TableRowRole) Cells() []html.HTMLElement { return rowCells(r.HTMLElement) }
+
+// Cell returns the cell in the column. A fatal error is generated if the
+// column doesn't exist.
+func (r TableRowRole) Cell(column string) html.HTMLElement {
+	r.table.t.Helper()
+	r.table.assertColumn(column)
+	return r.cellIn(column)
+}
+
+func (r TableRowRole) cellIn(column string) html.HTMLElement {
+	return cellInColumn(columnHeaders(r.table.HTMLElement), r.HTMLElement, column)
+}
+
+// Record returns the contents of the row as a map from column header names to
+// cell text.
+func (r TableRowRole) Record() map[string]string {
+	headers := columnHeaders(r.table.HTMLElement)
+	res := make(map[string]string)
+	for _, h := range headers {
+		name := headerName(h)
+		res[name] = cellText(cellInColumn(headers, r.HTMLElement, name))
+	}
+	return res
+}
+
+type byCellPredicate struct{ column, value string }
+
+// ByCell is an [ElementPredicate] that matches table rows where the text of
+// the cell in the column equals value.
+//
+// See also: [TableRole.Row]
+func ByCell(column, value string) ElementPredicate {
+	return byCellPredicate{column, value}
+}
+
+func (p byCellPredicate) IsMatch(e dom.Element) bool {
+	if ariarole.GetElementRole(e) != ariarole.Row {
+		return false
+	}
+	table := ownerTable(e)
+	if table == nil {
+		return false
+	}
+	cell := cellInColumn(columnHeaders(table), e, p.column)
+	return cell != nil && cellText(cell) == p.value
+}
+
+func (p byCellPredicate) String() string {
+	return fmt.Sprintf("By cell: %s = %s", p.column, p.value)
+}
+
+// ownerTable returns the nearest ancestor with the role "table" or "grid".
+func ownerTable(e dom.Element) dom.Element {
+	for p := e.ParentElement(); p != nil; p = p.ParentElement() {
+		if isTable(p) {
+			return p
+		}
+	}
+	return nil
+}
+
+// tableRows returns all rows in the table, excluding rows of nested tables.
+func tableRows(table dom.Element) []html.HTMLElement {
+	var res []html.HTMLElement
+	for e := range NewScope(nil, table).FindAll(ByRole(ariarole.Row)) {
+		if e, ok := e.(html.HTMLElement); ok && ownerTable(e) == table {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func isCell(e dom.Element) bool {
+	switch ariarole.GetElementRole(e) {
+	case ariarole.Cell, ariarole.Gridcell, ariarole.Columnheader, ariarole.Rowheader:
+		return true
+	}
+	return false
+}
+
+func rowCells(row dom.Element) []html.HTMLElement {
+	var res []html.HTMLElement
+	for _, c := range row.Children().All() {
+		if c, ok := c.(html.HTMLElement); ok && isCell(c) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func isHeaderRow(row dom.Element) bool {
+	cells := rowCells(row)
+	for _, c := range cells {
+		if ariarole.GetElementRole(c) != ariarole.Columnheader {
+			return false
+		}
+	}
+	return len(cells) > 0
+}
+
+// columnHeaders returns the column headers of the table, indexed by column. A
+// header spanning multiple columns appear multiple times.
+func columnHeaders(table dom.Element) []html.HTMLElement {
+	for _, r := range tableRows(table) {
+		if isHeaderRow(r) {
+			var res []html.HTMLElement
+			for _, c := range rowCells(r) {
+				for range colspan(c) {
+					res = append(res, c)
+				}
+			}
+			return res
+		}
+	}
+	return nil
+}
+
+func colspan(cell dom.Element) int {
+	if v, ok := cell.GetAttribute("colspan"); ok {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 1
+}
+
+// cellInColumn returns the cell in the row belonging to the named column, or
+// nil if the row has no cell in that column.
+func cellInColumn(headers []html.HTMLElement, row dom.Element, column string) html.HTMLElement {
+	col := 0
+	for _, c := range rowCells(row) {
+		if ids, ok := c.GetAttribute("headers"); ok {
+			for _, id := range strings.Fields(ids) {
+				h := c.OwnerDocument().GetElementById(id)
+				if h != nil && headerName(h) == column {
+					return c
+				}
+			}
+		} else {
+			for i := col; i < col+colspan(c) && i < len(headers); i++ {
+				if headerName(headers[i]) == column {
+					return c
+				}
+			}
+		}
+		col += colspan(c)
+	}
+	return nil
+}
+
+// headerName returns the accessibility name of a header cell with whitespace
+// collapsed.
+func headerName(h dom.Element) string {
+	return strings.Join(strings.Fields(ElementName(h)), " ")
+}
+
+// cellText returns the text content of a cell with whitespace collapsed,
+// ignoring indentation in the HTML source.
+func cellText(cell dom.Element) string {
+	if cell == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(cell.TextContent()), " ")
+}
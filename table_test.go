@@ -0,0 +1,68 @@
+package shaman_test
+
+import (
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<table>
+			<caption>Orders</caption>
+			<thead>
+				<tr><th>Order</th><th>Status</th><th>Total</th></tr>
+			</thead>
+			<tbody>
+				<tr><th>41</th><td>Shipped</td><td>10.00</td></tr>
+				<tr><th>42</th><td>Pending</td><td>
+					25.00
+				</td></tr>
+			</tbody>
+		</table>`)
+	table := NewScope(t, doc).Table(ByName("Orders"))
+
+	assert.Equal(t, []string{"Order", "Status", "Total"}, table.Headers())
+	assert.Len(t, table.Rows(), 2)
+	assert.Equal(t, "Pending", table.Cell(ByCell("Order", "42"), "Status").TextContent())
+	assert.Equal(t, []string{"10.00", "25.00"}, table.ColumnValues("Total"))
+	assert.Equal(t, []map[string]string{
+		{"Order": "41", "Status": "Shipped", "Total": "10.00"},
+		{"Order": "42", "Status": "Pending", "Total": "25.00"},
+	}, table.ToRecords())
+}
+
+func TestTableHeadersAttribute(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<table aria-label="Stock">
+			<tr><th id="h-item">Item</th><th colspan="2">Count</th></tr>
+			<tr><td headers="h-item">Apples</td><td>3</td><td>5</td></tr>
+		</table>`)
+	table := NewScope(t, doc).Table(ByName("Stock"))
+	assert.Equal(t, []string{"Item", "Count"}, table.Headers())
+	assert.Equal(t, "3", table.Row(ByCell("Item", "Apples")).Cell("Count").TextContent(),
+		"Cell returns the first cell spanned by the header")
+}
+
+func TestARIAGrid(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<div role="grid" aria-label="Users">
+			<div role="row">
+				<span role="columnheader">Name</span>
+				<span role="columnheader">Email</span>
+			</div>
+			<div role="row">
+				<span role="gridcell">John</span>
+				<span role="gridcell">jd@example.com</span>
+			</div>
+		</div>`)
+	table := NewScope(t, doc).Table(ByName("Users"))
+	assert.Equal(t, []map[string]string{
+		{"Name": "John", "Email": "jd@example.com"},
+	}, table.ToRecords())
+}
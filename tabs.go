@@ -0,0 +1,141 @@
+package shaman
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+// Tabs finds the element with the role "tablist" that matches the options.
+func (s Scope) Tabs(opts ...ElementPredicate) TabsRole {
+	s.t.Helper()
+	opts = append(opts, ByRole(ariarole.Tablist))
+	return TabsRole{s.Get(opts...), s.t}
+}
+
+// TabsRole is a helper to interact with a [tabs widget]; a list of tabs, each
+// associated with a panel of content, where only the panel of the selected tab
+// is displayed.
+//
+// The panel of a tab is the element referenced by the tab's aria-controls
+// attribute; or the "tabpanel" element with an aria-labelledby attribute
+// referencing the tab.
+//
+// [tabs widget]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Reference/Roles/tab_role
+type TabsRole struct {
+	html.HTMLElement
+	t testing.TB
+}
+
+func (tl TabsRole) unwrap() html.HTMLElement { return tl.HTMLElement }
+
+// Tabs returns the tabs in the tab list.
+func (tl TabsRole) Tabs() []html.HTMLElement {
+	var res []html.HTMLElement
+	for e := range NewScope(tl.t, tl.HTMLElement).FindAll(ByRole(ariarole.Tab)) {
+		if e, ok := e.(html.HTMLElement); ok {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// Selected returns the selected tab, or nil if no tab is selected.
+func (tl TabsRole) Selected() html.HTMLElement {
+	for _, tab := range tl.Tabs() {
+		if tabSelected(tab) {
+			return tab
+		}
+	}
+	return nil
+}
+
+// Select clicks the tab with the accessibility name, and generates an error if
+// the tab isn't selected as a result.
+func (tl TabsRole) Select(name string) {
+	tl.t.Helper()
+	tabs := tl.Tabs()
+	i := slices.IndexFunc(tabs, func(e html.HTMLElement) bool { return ElementName(e) == name })
+	if i == -1 {
+		names := make([]string, len(tabs))
+		for i, tab := range tabs {
+			names[i] = ElementName(tab)
+		}
+		tl.t.Fatalf("No tab named %q. Tabs: %s", name, strings.Join(names, ", "))
+		return
+	}
+	tab := tabs[i]
+	tab.Click()
+	if !tabSelected(tab) {
+		tl.t.Errorf("Tab %q not selected after click: %s", name, tab.OuterHTML())
+	}
+}
+
+// ActivePanel returns a [Scope] for the panel of the selected tab. A fatal
+// error is generated if no tab is selected, or the tab has no panel.
+func (tl TabsRole) ActivePanel() Scope {
+	tl.t.Helper()
+	tab := tl.Selected()
+	if tab == nil {
+		tl.t.Fatalf("No tab selected: %s", tl.OuterHTML())
+		return Scope{}
+	}
+	panel := tabPanel(tab)
+	if panel == nil {
+		tl.t.Fatalf("No panel for tab: %s", tab.OuterHTML())
+		return Scope{}
+	}
+	return NewScope(tl.t, panel)
+}
+
+// Validate generates an error unless exactly one tab is selected, and every tab
+// has a panel.
+func (tl TabsRole) Validate() {
+	tl.t.Helper()
+	tabs := tl.Tabs()
+	var selected []string
+	for _, tab := range tabs {
+		if tabSelected(tab) {
+			selected = append(selected, ElementName(tab))
+		}
+		if tabPanel(tab) == nil {
+			tl.t.Errorf("No panel for tab %q: %s", ElementName(tab), tab.OuterHTML())
+		}
+	}
+	if len(selected) != 1 {
+		tl.t.Errorf(
+			"Expected exactly one selected tab, found %d: %s",
+			len(selected), strings.Join(selected, ", "),
+		)
+	}
+}
+
+func tabSelected(tab dom.Element) bool {
+	v, _ := tab.GetAttribute("aria-selected")
+	return v == "true"
+}
+
+// tabPanel returns the panel associated with the tab, or nil if none is found.
+func tabPanel(tab dom.Element) dom.Element {
+	doc := tab.OwnerDocument()
+	if id, ok := tab.GetAttribute("aria-controls"); ok {
+		if panel := doc.GetElementById(id); panel != nil {
+			return panel
+		}
+	}
+	id := tab.ID()
+	if id == "" {
+		return nil
+	}
+	for e := range NewScope(nil, doc).FindAll(ByRole(ariarole.Tabpanel)) {
+		if ids, _ := e.GetAttribute("aria-labelledby"); slices.Contains(strings.Fields(ids), id) {
+			return e
+		}
+	}
+	return nil
+}
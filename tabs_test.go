@@ -0,0 +1,57 @@
+package shaman_test
+
+import (
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+	"github.com/gost-dom/shaman/ariarole"
+	"github.com/gost-dom/shaman/internal/testtb"
+
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTabs(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<div role="tablist" aria-label="Settings">
+			<button role="tab" id="tab-profile" aria-selected="true"
+				aria-controls="panel-profile">Profile</button>
+			<button role="tab" id="tab-security" aria-selected="false">Security</button>
+		</div>
+		<div role="tabpanel" id="panel-profile"><h2>Your profile</h2></div>
+		<div role="tabpanel" aria-labelledby="tab-security" hidden><h2>Password</h2></div>`)
+	scope := NewScope(t, doc)
+	tabs := scope.Tabs(ByName("Settings"))
+	for _, tab := range tabs.Tabs() {
+		tab.AddEventListener("click",
+			event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+				for _, other := range tabs.Tabs() {
+					other.SetAttribute("aria-selected", "false")
+				}
+				tab.SetAttribute("aria-selected", "true")
+			}))
+	}
+
+	tabs.Validate()
+	assert.Equal(t, "Profile", ElementName(tabs.Selected()))
+	assert.Equal(t, "Your profile",
+		tabs.ActivePanel().Get(ByRole(ariarole.Tabpanel)).TextContent())
+
+	tabs.Select("Security")
+	assert.Equal(t, "Security", ElementName(tabs.Selected()))
+	assert.Equal(t, "Password",
+		tabs.ActivePanel().Get(ByRole(ariarole.Tabpanel)).TextContent())
+}
+
+func TestTabsValidate(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<div role="tablist" aria-label="Settings">
+			<button role="tab" aria-selected="true">Profile</button>
+			<button role="tab" aria-selected="true">Security</button>
+		</div>`)
+	tb := &testtb.RecordingTB{TB: t}
+	NewScope(tb, doc).Tabs(ByName("Settings")).Validate()
+	assert.Len(t, tb.Errors, 3, "Two tabs without panels, and two selected tabs")
+}
@@ -0,0 +1,263 @@
+package shaman
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gost-dom/shaman/ariarole"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+type treePredicate struct{}
+
+func (treePredicate) IsMatch(e dom.Element) bool {
+	switch ariarole.GetElementRole(e) {
+	case ariarole.Tree, ariarole.Treegrid:
+		return true
+	}
+	return false
+}
+
+func (treePredicate) String() string { return "By role: tree, or treegrid" }
+
+// Tree finds the element with the role "tree" or "treegrid" that matches the
+// options.
+func (s Scope) Tree(opts ...ElementPredicate) TreeRole {
+	s.t.Helper()
+	opts = append(opts, treePredicate{})
+	return TreeRole{s.Get(opts...), s.t}
+}
+
+// TreeNode describes a tree item, and its visible children.
+type TreeNode struct {
+	Name  string
+	Level int
+	// Expandable tells if the item has children, i.e., has an aria-expanded
+	// attribute.
+	Expandable bool
+	Expanded   bool
+	Selected   bool
+	// Children contains the child items. Children of collapsed items are not
+	// included.
+	Children []TreeNode
+}
+
+// TreeRole is a helper to interact with a [tree], e.g., a file browser, or a
+// [treegrid]. The items of a treegrid are its rows, named by the first cell.
+//
+// The level of a tree item is the value of aria-level, if present; otherwise
+// calculated by the nesting of tree items. The parent of a tree item is the
+// closest preceding item on the level above, making this work for trees
+// represented by both nested groups, and flat lists using aria-level.
+//
+// Items are identified by a path of names from the top level, e.g.,
+// Expand("Documents", "Invoices").
+//
+// [tree]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Reference/Roles/tree_role
+// [treegrid]: https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Reference/Roles/treegrid_role
+type TreeRole struct {
+	html.HTMLElement
+	t testing.TB
+}
+
+func (tr TreeRole) unwrap() html.HTMLElement { return tr.HTMLElement }
+
+type treeEntry struct {
+	html.HTMLElement
+	level  int
+	parent int
+}
+
+// entries returns all tree items in document order, with the index of the
+// parent item, or -1 for top-level items. The items of a treegrid are its rows,
+// except header rows.
+func (tr TreeRole) entries() []treeEntry {
+	var res []treeEntry
+	var pred ElementPredicate = ByRole(ariarole.Treeitem)
+	if ariarole.GetElementRole(tr.HTMLElement) == ariarole.Treegrid {
+		pred = ElementPredicateFunc(treegridRow)
+	}
+	for e := range NewScope(tr.t, tr.HTMLElement).FindAll(pred) {
+		e, ok := e.(html.HTMLElement)
+		if !ok {
+			continue
+		}
+		entry := treeEntry{e, treeItemLevel(e), -1}
+		for i := len(res) - 1; i >= 0; i-- {
+			if res[i].level < entry.level {
+				entry.parent = i
+				break
+			}
+		}
+		res = append(res, entry)
+	}
+	return res
+}
+
+// Walk returns the visible hierarchy of tree items, i.e., the children of
+// collapsed items are not included.
+func (tr TreeRole) Walk() []TreeNode { return walkTree(tr.entries(), -1) }
+
+func walkTree(entries []treeEntry, parent int) []TreeNode {
+	var res []TreeNode
+	for i, e := range entries {
+		if e.parent != parent {
+			continue
+		}
+		node := TreeNode{
+			Name:       treeItemName(e),
+			Level:      e.level,
+			Expandable: e.HasAttribute("aria-expanded"),
+			Expanded:   popupExpanded(e),
+			Selected:   treeItemSelected(e),
+		}
+		if node.Expanded {
+			node.Children = walkTree(entries, i)
+		}
+		res = append(res, node)
+	}
+	return res
+}
+
+// Item returns the tree item at the path, expanding all items leading to it.
+func (tr TreeRole) Item(path ...string) html.HTMLElement {
+	tr.t.Helper()
+	if len(path) == 0 {
+		tr.t.Fatalf("Tree item path is empty")
+		return nil
+	}
+	parent := -1
+	for i, name := range path {
+		entries := tr.entries()
+		var names []string
+		idx := slices.IndexFunc(entries, func(e treeEntry) bool {
+			if e.parent != parent {
+				return false
+			}
+			names = append(names, treeItemName(e))
+			return treeItemName(e) == name
+		})
+		if idx == -1 {
+			tr.t.Fatalf("No tree item named %q. Items: %s", name, strings.Join(names, ", "))
+			return nil
+		}
+		if i == len(path)-1 {
+			return entries[idx]
+		}
+		tr.expand(entries[idx].HTMLElement)
+		parent = idx
+	}
+	return nil
+}
+
+// Expand expands the tree item at the path, as well as all items leading to
+// it.
+func (tr TreeRole) Expand(path ...string) {
+	tr.t.Helper()
+	tr.expand(tr.Item(path...))
+}
+
+// Collapse collapses the tree item at the path. Items leading to it are
+// expanded.
+func (tr TreeRole) Collapse(path ...string) {
+	tr.t.Helper()
+	item := tr.Item(path...)
+	if !popupExpanded(item) {
+		return
+	}
+	item.Focus()
+	if pressKey(item, "ArrowLeft"); popupExpanded(item) {
+		item.Click()
+	}
+	if popupExpanded(item) {
+		tr.t.Errorf("Tree item not collapsed: %s", item.OuterHTML())
+	}
+}
+
+// Select clicks the tree item at the path. Items leading to it are expanded.
+func (tr TreeRole) Select(path ...string) {
+	tr.t.Helper()
+	tr.Item(path...).Click()
+}
+
+// expand expands the tree item, by pressing the right arrow key, or clicking it
+// if that had no effect.
+func (tr TreeRole) expand(item html.HTMLElement) {
+	tr.t.Helper()
+	if !item.HasAttribute("aria-expanded") {
+		tr.t.Fatalf("Tree item cannot be expanded: %s", item.OuterHTML())
+		return
+	}
+	if popupExpanded(item) {
+		return
+	}
+	item.Focus()
+	if pressKey(item, "ArrowRight"); !popupExpanded(item) {
+		item.Click()
+	}
+	if !popupExpanded(item) {
+		tr.t.Fatalf("Tree item not expanded: %s", item.OuterHTML())
+	}
+}
+
+// treegridRow returns whether e is a row of a treegrid, that isn't a header
+// row.
+func treegridRow(e dom.Element) bool {
+	if ariarole.GetElementRole(e) != ariarole.Row {
+		return false
+	}
+	for range NewScope(nil, e).FindAll(ByRole(ariarole.Columnheader)) {
+		return false
+	}
+	return true
+}
+
+// treeItemName returns the name of a tree item. Unless labelled, a treegrid row
+// is named by its first cell.
+func treeItemName(e dom.Element) string {
+	if ariarole.GetElementRole(e) != ariarole.Row {
+		return ElementName(e)
+	}
+	if name := strings.TrimSpace(ElementName(e)); name != "" {
+		return name
+	}
+	for c := range NewScope(nil, e).FindAll(ElementPredicateFunc(func(c dom.Element) bool {
+		switch ariarole.GetElementRole(c) {
+		case ariarole.Cell, ariarole.Gridcell, ariarole.Rowheader:
+			return true
+		}
+		return false
+	})) {
+		return strings.TrimSpace(ElementName(c))
+	}
+	return ""
+}
+
+// treeItemLevel returns the value of aria-level, or the number of tree items
+// containing the item, plus one.
+func treeItemLevel(e dom.Element) int {
+	if v, ok := e.GetAttribute("aria-level"); ok {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	level := 1
+	for p := e.ParentElement(); p != nil; p = p.ParentElement() {
+		switch ariarole.GetElementRole(p) {
+		case ariarole.Treeitem:
+			level++
+		case ariarole.Tree, ariarole.Treegrid:
+			return level
+		}
+	}
+	return level
+}
+
+func treeItemSelected(e dom.Element) bool {
+	v, _ := e.GetAttribute("aria-selected")
+	return v == "true"
+}
@@ -0,0 +1,105 @@
+package shaman_test
+
+import (
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/dom/event"
+	"github.com/stretchr/testify/assert"
+)
+
+// toggleOnClick simulates a tree widget, where clicking an item toggles the
+// expanded state.
+func toggleOnClick(doc dom.Document) {
+	doc.AddEventListener("click",
+		event.NewEventHandlerFuncWithoutError(func(e *event.Event) {
+			item := e.Target.(dom.Element)
+			switch v, _ := item.GetAttribute("aria-expanded"); v {
+			case "true":
+				item.SetAttribute("aria-expanded", "false")
+			case "false":
+				item.SetAttribute("aria-expanded", "true")
+			default:
+				item.SetAttribute("aria-selected", "true")
+			}
+		}))
+}
+
+func TestTreeNested(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<ul role="tree" aria-label="Files">
+			<li role="treeitem" aria-expanded="false">Documents
+				<ul role="group">
+					<li role="treeitem" aria-expanded="false">Invoices
+						<ul role="group">
+							<li role="treeitem">2024.pdf</li>
+						</ul>
+					</li>
+					<li role="treeitem">notes.txt</li>
+				</ul>
+			</li>
+			<li role="treeitem">readme.md</li>
+		</ul>`)
+	toggleOnClick(doc)
+	tree := NewScope(t, doc).Tree(ByName("Files"))
+	assert.Equal(t, []TreeNode{
+		{Name: "Documents", Level: 1, Expandable: true},
+		{Name: "readme.md", Level: 1},
+	}, tree.Walk())
+
+	tree.Expand("Documents", "Invoices")
+	tree.Select("Documents", "Invoices", "2024.pdf")
+	assert.Equal(t, []TreeNode{
+		{Name: "Documents", Level: 1, Expandable: true, Expanded: true, Children: []TreeNode{
+			{Name: "Invoices", Level: 2, Expandable: true, Expanded: true, Children: []TreeNode{
+				{Name: "2024.pdf", Level: 3, Selected: true},
+			}},
+			{Name: "notes.txt", Level: 2},
+		}},
+		{Name: "readme.md", Level: 1},
+	}, tree.Walk())
+
+	tree.Collapse("Documents")
+	assert.False(t, tree.Walk()[0].Expanded)
+}
+
+func TestTreeFlatWithARIALevel(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<div role="tree" aria-label="Files">
+			<div role="treeitem" aria-level="1" aria-expanded="true">src</div>
+			<div role="treeitem" aria-level="2">main.go</div>
+			<div role="treeitem" aria-level="1">go.mod</div>
+		</div>`)
+	tree := NewScope(t, doc).Tree(ByName("Files"))
+	assert.Equal(t, []TreeNode{
+		{Name: "src", Level: 1, Expandable: true, Expanded: true, Children: []TreeNode{
+			{Name: "main.go", Level: 2},
+		}},
+		{Name: "go.mod", Level: 1},
+	}, tree.Walk())
+}
+
+func TestTreegrid(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<table role="treegrid" aria-label="Files">
+			<thead><tr><th>Name</th><th>Size</th></tr></thead>
+			<tbody>
+				<tr aria-level="1" aria-expanded="true"><td>src</td><td></td></tr>
+				<tr aria-level="2" aria-selected="true"><td>main.go</td><td>2 KB</td></tr>
+				<tr aria-level="1"><td>go.mod</td><td>1 KB</td></tr>
+			</tbody>
+		</table>`)
+	tree := NewScope(t, doc).Tree(ByName("Files"))
+	assert.Equal(t, []TreeNode{
+		{Name: "src", Level: 1, Expandable: true, Expanded: true, Children: []TreeNode{
+			{Name: "main.go", Level: 2, Selected: true},
+		}},
+		{Name: "go.mod", Level: 1},
+	}, tree.Walk())
+	assert.Equal(t, "main.go2 KB", tree.Item("src", "main.go").TextContent())
+}
@@ -0,0 +1,385 @@
+package shaman
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gost-dom/browser/dom"
+	"github.com/gost-dom/browser/html"
+)
+
+// ValidityState mirrors the [ValidityState] of the constraint validation API,
+// telling which constraints an element fails to satisfy.
+//
+// CustomError represents an element marked invalid with aria-invalid, e.g., by
+// validation on the server, as well as by custom client-side validation.
+//
+// [ValidityState]: https://developer.mozilla.org/en-US/docs/Web/API/ValidityState
+type ValidityState struct {
+	ValueMissing    bool
+	TypeMismatch    bool
+	PatternMismatch bool
+	TooLong         bool
+	TooShort        bool
+	RangeUnderflow  bool
+	RangeOverflow   bool
+	StepMismatch    bool
+	BadInput        bool
+	CustomError     bool
+}
+
+// Valid returns whether the element satisfies all constraints.
+func (s ValidityState) Valid() bool { return s == ValidityState{} }
+
+// ElementValidity returns the validity state of an element, combining the
+// constraints of native form controls, e.g., required, pattern, and min; and
+// the aria-invalid attribute.
+//
+// Unlike a browser, maxlength and minlength are checked for values not entered
+// by the user.
+func ElementValidity(e dom.Element) ValidityState {
+	var s ValidityState
+	if e == nil {
+		return s
+	}
+	s.CustomError = ariaInvalid(e)
+	if !validationCandidate(e) {
+		return s
+	}
+	switch e.TagName() {
+	case "SELECT":
+		s.ValueMissing = e.HasAttribute("required") && selectValueMissing(e)
+	case "TEXTAREA":
+		value := e.TextContent()
+		s.ValueMissing = e.HasAttribute("required") && value == ""
+		s.TooLong, s.TooShort = lengthMismatch(e, value)
+	case "INPUT":
+		if input, ok := e.(html.HTMLInputElement); ok {
+			inputValidity(input, &s)
+		}
+	}
+	return s
+}
+
+func inputValidity(input html.HTMLInputElement, s *ValidityState) {
+	value := input.Value()
+	required := input.HasAttribute("required")
+	switch input.Type() {
+	case "checkbox":
+		s.ValueMissing = required && !inputChecked(input)
+		return
+	case "radio":
+		s.ValueMissing = radioValueMissing(input)
+		return
+	case "number", "range":
+		s.ValueMissing = required && value == ""
+		if value == "" {
+			return
+		}
+		v := parseFloat(value, math.NaN())
+		if math.IsNaN(v) {
+			s.BadInput = true
+			return
+		}
+		s.RangeUnderflow = v < floatAttribute(input, "min", math.Inf(-1))
+		s.RangeOverflow = v > floatAttribute(input, "max", math.Inf(1))
+		s.StepMismatch = stepMismatch(input, v)
+		return
+	}
+	s.ValueMissing = required && value == ""
+	if value == "" {
+		return
+	}
+	values := []string{value}
+	if input.Type() == "email" && input.HasAttribute("multiple") {
+		values = strings.Split(value, ",")
+		for i, v := range values {
+			values[i] = strings.TrimSpace(v)
+		}
+	}
+	for _, v := range values {
+		switch input.Type() {
+		case "email":
+			s.TypeMismatch = s.TypeMismatch || !emailPattern.MatchString(v)
+		case "url":
+			u, err := url.Parse(v)
+			s.TypeMismatch = s.TypeMismatch || err != nil || !u.IsAbs()
+		}
+		if pattern, ok := patternAttribute(input); ok {
+			s.PatternMismatch = s.PatternMismatch || !pattern.MatchString(v)
+		}
+	}
+	s.TooLong, s.TooShort = lengthMismatch(input, value)
+}
+
+// ElementValidationMessage returns the message describing why an element is
+// invalid; the text of the elements referenced by aria-errormessage for an
+// element with aria-invalid, otherwise a message for the first failing native
+// constraint, similar to the message displayed by a browser. Returns an empty
+// string if the element is valid.
+func ElementValidationMessage(e dom.Element) string {
+	s := ElementValidity(e)
+	if s.CustomError {
+		if msg := errorMessage(e); msg != "" {
+			return msg
+		}
+	}
+	value, _ := e.GetAttribute("value")
+	if e.TagName() == "TEXTAREA" {
+		value = e.TextContent()
+	} else if input, ok := e.(html.HTMLInputElement); ok {
+		value = input.Value()
+	}
+	switch {
+	case s.ValueMissing:
+		switch typ, _ := e.GetAttribute("type"); {
+		case e.TagName() == "SELECT":
+			return "Please select an item in the list."
+		case typ == "checkbox":
+			return "Please check this box if you want to proceed."
+		case typ == "radio":
+			return "Please select one of these options."
+		}
+		return "Please fill out this field."
+	case s.BadInput:
+		return "Please enter a number."
+	case s.TypeMismatch:
+		if typ, _ := e.GetAttribute("type"); typ == "url" {
+			return "Please enter a URL."
+		}
+		return "Please enter an email address."
+	case s.PatternMismatch:
+		if title, ok := e.GetAttribute("title"); ok {
+			return "Please match the requested format: " + title
+		}
+		return "Please match the requested format."
+	case s.TooLong:
+		max, _ := e.GetAttribute("maxlength")
+		return fmt.Sprintf(
+			"Please shorten this text to %s characters or less (you are currently using %d characters).",
+			max, utf8.RuneCountInString(value),
+		)
+	case s.TooShort:
+		min, _ := e.GetAttribute("minlength")
+		return fmt.Sprintf(
+			"Please lengthen this text to %s characters or more (you are currently using %d characters).",
+			min, utf8.RuneCountInString(value),
+		)
+	case s.RangeUnderflow:
+		min, _ := e.GetAttribute("min")
+		return "Value must be greater than or equal to " + min + "."
+	case s.RangeOverflow:
+		max, _ := e.GetAttribute("max")
+		return "Value must be less than or equal to " + max + "."
+	case s.StepMismatch:
+		return "Please enter a valid value."
+	}
+	return ""
+}
+
+// Validity returns the validity state of the textbox, see [ElementValidity].
+func (tb TextboxRole) Validity() ValidityState { return ElementValidity(tb.HTMLElement) }
+
+// ValidationMessage returns the validation message of the textbox, see
+// [ElementValidationMessage].
+func (tb TextboxRole) ValidationMessage() string { return ElementValidationMessage(tb.HTMLElement) }
+
+// Validity returns the validity state of the checkbox, see [ElementValidity].
+func (cb CheckboxRole) Validity() ValidityState { return ElementValidity(cb.HTMLElement) }
+
+// ValidationMessage returns the validation message of the checkbox, see
+// [ElementValidationMessage].
+func (cb CheckboxRole) ValidationMessage() string { return ElementValidationMessage(cb.HTMLElement) }
+
+// Validity returns the validity state of the combobox, see [ElementValidity].
+func (cb ComboboxRole) Validity() ValidityState { return ElementValidity(cb.HTMLElement) }
+
+// ValidationMessage returns the validation message of the combobox, see
+// [ElementValidationMessage].
+func (cb ComboboxRole) ValidationMessage() string { return ElementValidationMessage(cb.HTMLElement) }
+
+// Validity returns the validity state of the listbox, see [ElementValidity].
+func (lb ListboxRole) Validity() ValidityState { return ElementValidity(lb.HTMLElement) }
+
+// ValidationMessage returns the validation message of the listbox, see
+// [ElementValidationMessage].
+func (lb ListboxRole) ValidationMessage() string { return ElementValidationMessage(lb.HTMLElement) }
+
+// Validity returns the validity state of the element, see [ElementValidity].
+func (r RangeRole) Validity() ValidityState { return ElementValidity(r.HTMLElement) }
+
+// ValidationMessage returns the validation message of the element, see
+// [ElementValidationMessage].
+func (r RangeRole) ValidationMessage() string { return ElementValidationMessage(r.HTMLElement) }
+
+// Validity returns the validity state of the group; aria-invalid on the group
+// element, and whether a required radio button is missing a value.
+func (g RadioGroupRole) Validity() ValidityState {
+	s := ElementValidity(g.HTMLElement)
+	for _, o := range g.Options() {
+		s.ValueMissing = s.ValueMissing || ElementValidity(o).ValueMissing
+	}
+	return s
+}
+
+// ValidationMessage returns the validation message of the group element, or
+// otherwise the first radio button with a validation message.
+func (g RadioGroupRole) ValidationMessage() string {
+	if msg := ElementValidationMessage(g.HTMLElement); msg != "" {
+		return msg
+	}
+	for _, o := range g.Options() {
+		if msg := ElementValidationMessage(o); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// InvalidFields returns the elements in the form that are invalid, in document
+// order; form controls failing native constraints, and elements with
+// aria-invalid.
+func (f FormRole) InvalidFields() []html.HTMLElement {
+	var res []html.HTMLElement
+	controls := formElements(f.HTMLElement)
+	for e := range NewScope(nil, f.OwnerDocument()).All() {
+		e, ok := e.(html.HTMLElement)
+		if !ok {
+			continue
+		}
+		inForm := f.HTMLElement.Contains(e) || containsElement(controls, e)
+		if inForm && !ElementValidity(e).Valid() {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func containsElement(elements []html.HTMLElement, e dom.Element) bool {
+	for _, x := range elements {
+		if x == e {
+			return true
+		}
+	}
+	return false
+}
+
+// ariaInvalid returns whether aria-invalid has a value other than "false".
+func ariaInvalid(e dom.Element) bool {
+	v, ok := e.GetAttribute("aria-invalid")
+	return ok && v != "" && v != "false"
+}
+
+// errorMessage returns the text of the elements referenced by
+// aria-errormessage.
+func errorMessage(e dom.Element) string {
+	ids, _ := e.GetAttribute("aria-errormessage")
+	var msgs []string
+	for _, id := range strings.Fields(ids) {
+		if m := e.OwnerDocument().GetElementById(id); m != nil {
+			msgs = append(msgs, cellText(m))
+		}
+	}
+	return strings.Join(msgs, " ")
+}
+
+// validationCandidate returns whether native constraints apply to the element.
+func validationCandidate(e dom.Element) bool {
+	switch e.TagName() {
+	case "INPUT":
+		switch typ, _ := e.GetAttribute("type"); strings.ToLower(typ) {
+		case "hidden", "button", "reset", "submit", "image":
+			return false
+		}
+	case "SELECT", "TEXTAREA":
+	default:
+		return false
+	}
+	return !disabled(e) && !e.HasAttribute("readonly") && closest(e, "DATALIST") == nil
+}
+
+// The valid e-mail address definition of the HTML standard.
+var emailPattern = regexp.MustCompile(
+	"^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?" +
+		"(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$",
+)
+
+// patternAttribute compiles the pattern attribute, which must match the entire
+// value. Returns false if there is no pattern, or it isn't supported by the Go
+// regexp package.
+func patternAttribute(e dom.Element) (*regexp.Regexp, bool) {
+	pattern, ok := e.GetAttribute("pattern")
+	if !ok {
+		return nil, false
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	return re, err == nil
+}
+
+func lengthMismatch(e dom.Element, value string) (tooLong, tooShort bool) {
+	length := utf8.RuneCountInString(value)
+	if max := floatAttribute(e, "maxlength", -1); max >= 0 {
+		tooLong = float64(length) > max
+	}
+	if min := floatAttribute(e, "minlength", -1); min >= 0 {
+		tooShort = length > 0 && float64(length) < min
+	}
+	return
+}
+
+func stepMismatch(input html.HTMLInputElement, v float64) bool {
+	if s, _ := input.GetAttribute("step"); strings.EqualFold(s, "any") {
+		return false
+	}
+	step := floatAttribute(input, "step", 1)
+	if step <= 0 {
+		step = 1
+	}
+	base := floatAttribute(input, "min", floatAttribute(input, "value", 0))
+	n := (v - base) / step
+	return math.Abs(n-math.Round(n)) > 1e-9
+}
+
+func selectValueMissing(sel dom.Element) bool {
+	for _, o := range selectedFormOptions(sel) {
+		if optionValue(o) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// radioValueMissing returns whether a radio button is in a group where a
+// button is required, and no button is checked.
+func radioValueMissing(input html.HTMLInputElement) bool {
+	group := []html.HTMLInputElement{input}
+	if name, _ := input.GetAttribute("name"); name != "" {
+		group = nil
+		var root dom.ElementContainer = input.OwnerDocument()
+		form := formOwner(input)
+		if form != nil {
+			root = form
+		}
+		for e := range NewScope(nil, root).All() {
+			r, ok := e.(html.HTMLInputElement)
+			if !ok || r.Type() != "radio" || formOwner(r) != form {
+				continue
+			}
+			if n, _ := r.GetAttribute("name"); n == name {
+				group = append(group, r)
+			}
+		}
+	}
+	var required bool
+	for _, r := range group {
+		if inputChecked(r) {
+			return false
+		}
+		required = required || r.HasAttribute("required")
+	}
+	return required
+}
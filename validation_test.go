@@ -0,0 +1,114 @@
+package shaman_test
+
+import (
+	"testing"
+
+	. "github.com/gost-dom/shaman"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElementValidity(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<input id="required" required />
+		<input id="email" type="email" value="not an email" />
+		<input id="emails" type="email" multiple value="a@example.com, b@example.com" />
+		<input id="url" type="url" value="example.com" />
+		<input id="pattern" pattern="[0-9]{4}" title="Four digits" value="123" />
+		<input id="too-long" maxlength="3" value="abcd" />
+		<input id="too-short" minlength="3" value="ab" />
+		<input id="empty-short" minlength="3" />
+		<input id="under" type="number" min="1" value="0" />
+		<input id="over" type="number" max="10" value="11" />
+		<input id="step" type="number" min="1" step="2" value="2" />
+		<input id="bad" type="number" value="abc" />
+		<input id="disabled" required disabled />
+		<input id="checkbox" type="checkbox" required />
+		<input type="radio" name="r" required />
+		<input id="radio" type="radio" name="r" />
+		<select id="select" required>
+			<option value="">Choose</option>
+			<option value="1">One</option>
+		</select>
+		<textarea id="textarea" required></textarea>
+		<input id="custom" aria-invalid="true" aria-errormessage="custom-error" />
+		<span id="custom-error">  Username   is taken </span>`)
+
+	validity := func(id string) ValidityState {
+		return ElementValidity(doc.GetElementById(id))
+	}
+	message := func(id string) string {
+		return ElementValidationMessage(doc.GetElementById(id))
+	}
+
+	assert.Equal(t, ValidityState{ValueMissing: true}, validity("required"))
+	assert.Equal(t, "Please fill out this field.", message("required"))
+	assert.Equal(t, ValidityState{TypeMismatch: true}, validity("email"))
+	assert.True(t, validity("emails").Valid())
+	assert.Equal(t, ValidityState{TypeMismatch: true}, validity("url"))
+	assert.Equal(t, "Please enter a URL.", message("url"))
+	assert.Equal(t, ValidityState{PatternMismatch: true}, validity("pattern"))
+	assert.Equal(t, "Please match the requested format: Four digits", message("pattern"))
+	assert.Equal(t, ValidityState{TooLong: true}, validity("too-long"))
+	assert.Equal(t, ValidityState{TooShort: true}, validity("too-short"))
+	assert.Equal(t,
+		"Please lengthen this text to 3 characters or more (you are currently using 2 characters).",
+		message("too-short"))
+	assert.True(t, validity("empty-short").Valid(), "Empty values aren't too short")
+	assert.Equal(t, ValidityState{RangeUnderflow: true}, validity("under"))
+	assert.Equal(t, "Value must be greater than or equal to 1.", message("under"))
+	assert.Equal(t, ValidityState{RangeOverflow: true}, validity("over"))
+	assert.Equal(t, ValidityState{StepMismatch: true}, validity("step"))
+	assert.Equal(t, ValidityState{BadInput: true}, validity("bad"))
+	assert.True(t, validity("disabled").Valid(), "Disabled elements are not validated")
+	assert.Equal(t, ValidityState{ValueMissing: true}, validity("checkbox"))
+	assert.Equal(t, ValidityState{ValueMissing: true}, validity("radio"),
+		"Any required radio button in the group makes the group required")
+	assert.Equal(t, ValidityState{ValueMissing: true}, validity("select"))
+	assert.Equal(t, "Please select an item in the list.", message("select"))
+	assert.Equal(t, ValidityState{ValueMissing: true}, validity("textarea"))
+	assert.Equal(t, ValidityState{CustomError: true}, validity("custom"))
+	assert.Equal(t, "Username is taken", message("custom"))
+}
+
+func TestFormInvalidFields(t *testing.T) {
+	t.Parallel()
+	doc := loadHTML(t, `
+		<form aria-label="Sign up">
+			<label>Email <input type="email" name="email" required /></label>
+			<label>Username
+				<input name="username" value="jd"
+					aria-invalid="true" aria-errormessage="username-error" />
+			</label>
+			<span id="username-error">Username is taken</span>
+			<label><input type="checkbox" name="terms" required /> Accept terms</label>
+			<fieldset>
+				<legend>Plan</legend>
+				<label><input type="radio" name="plan" value="free" required /> Free</label>
+				<label><input type="radio" name="plan" value="pro" /> Pro</label>
+			</fieldset>
+		</form>`)
+	form := NewScope(t, doc).Form(ByName("Sign up"))
+
+	names := func() []string {
+		var res []string
+		for _, e := range form.InvalidFields() {
+			res = append(res, ElementName(e))
+		}
+		return res
+	}
+	assert.Equal(t, []string{"Email", "Username", "Accept terms", "Free", "Pro"}, names())
+	assert.Equal(t, "Username is taken", form.Textbox(ByName("Username")).ValidationMessage())
+	assert.Equal(t, "Please select one of these options.",
+		form.RadioGroup(ByName("Plan")).ValidationMessage())
+
+	form.Fill(map[string]string{
+		"Email":        "not an email",
+		"Accept terms": "on",
+		"Plan":         "Pro",
+	})
+	assert.Equal(t, []string{"Email", "Username"}, names())
+	assert.Equal(t, ValidityState{TypeMismatch: true}, form.Textbox(ByName("Email")).Validity())
+	assert.True(t, form.RadioGroup(ByName("Plan")).Validity().Valid())
+}